@@ -22,3 +22,9 @@ func (i *LocalLoadableConfig) Sources() []config.Source {
 		return file, nil
 	}}
 }
+
+// Exists checks if the config file is located in pkg/assets/config directory
+func (i *LocalLoadableConfig) Exists() bool {
+	_, err := assets.Asset(i.ConfigFileName)
+	return err == nil
+}
@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// FileSink stores audit records in a file in JSON lines format (one record per line)
+type FileSink struct {
+	Path string
+	lock sync.Mutex
+	// offset is the position in the file up to which the records have been already indexed
+	offset int64
+	// indexed holds at most MaxLimit of the latest records per repository, so the file is not reread for every query
+	indexed map[string][]Record
+}
+
+// NewFileSink creates an instance of FileSink appending the records to the file located at the given path
+func NewFileSink(path string) *FileSink {
+	return &FileSink{Path: path, indexed: map[string][]Record{}}
+}
+
+// Write appends the given record to the file
+func (s *FileSink) Write(record Record) (err error) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// Recent returns at most limit of the latest records for the given repository - the newest first. Only the part
+// of the file appended since the previous call is read, and at most MaxLimit records per repository are kept.
+// Lines that can't be unmarshaled are skipped
+func (s *FileSink) Recent(repository string, limit int) ([]Record, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.indexNewRecords(); err != nil {
+		return nil, err
+	}
+
+	matching := s.indexed[repository]
+	records := make([]Record, 0, limit)
+	for i := len(matching) - 1; i >= 0 && len(records) < limit; i-- {
+		records = append(records, matching[i])
+	}
+	return records, nil
+}
+
+func (s *FileSink) indexNewRecords() (err error) {
+	file, err := os.Open(s.Path)
+	if os.IsNotExist(err) {
+		s.offset, s.indexed = 0, map[string][]Record{}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if s.indexed == nil || info.Size() < s.offset {
+		// the file has been truncated or replaced, so start over
+		s.offset, s.indexed = 0, map[string][]Record{}
+	}
+	if _, err := file.Seek(s.offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// incomplete line is left to be indexed once it's fully written
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.offset += int64(len(line))
+
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			continue
+		}
+		records := append(s.indexed[record.Repository], record)
+		if len(records) > MaxLimit {
+			records = records[len(records)-MaxLimit:]
+		}
+		s.indexed[record.Repository] = records
+	}
+}
@@ -0,0 +1,117 @@
+package audit_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/audit"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("File sink features", func() {
+
+	var (
+		dir  string
+		sink *audit.FileSink
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "audit")
+		Expect(err).ToNot(HaveOccurred())
+		sink = audit.NewFileSink(filepath.Join(dir, "audit.jsonl"))
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should append records as JSON lines", func() {
+		// when
+		Expect(sink.Write(audit.Record{Repository: "owner/repo", Command: "/run", User: "alien"})).To(Succeed())
+		Expect(sink.Write(audit.Record{Repository: "owner/repo", Command: "/ok-without-tests", User: "ike"})).To(Succeed())
+
+		// then
+		content, err := ioutil.ReadFile(sink.Path)
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring(`"command":"/run"`))
+		Expect(string(content)).To(ContainSubstring(`"command":"/ok-without-tests"`))
+		Expect(string(content)).To(HaveSuffix("}\n"))
+	})
+
+	It("should return the latest records of the given repository the newest first", func() {
+		// given
+		Expect(sink.Write(audit.Record{Repository: "owner/repo", PullRequest: 1})).To(Succeed())
+		Expect(sink.Write(audit.Record{Repository: "owner/other", PullRequest: 2})).To(Succeed())
+		Expect(sink.Write(audit.Record{Repository: "owner/repo", PullRequest: 3})).To(Succeed())
+		Expect(sink.Write(audit.Record{Repository: "owner/repo", PullRequest: 4})).To(Succeed())
+
+		// when
+		records, err := sink.Recent("owner/repo", 2)
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(records).To(HaveLen(2))
+		Expect(records[0].PullRequest).To(Equal(4))
+		Expect(records[1].PullRequest).To(Equal(3))
+	})
+
+	It("should return no records when the file doesn't exist", func() {
+		// when
+		records, err := sink.Recent("owner/repo", 10)
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(records).To(BeEmpty())
+	})
+
+	It("should skip lines that are not valid records", func() {
+		// given
+		Expect(ioutil.WriteFile(sink.Path, []byte("not a json\n"), 0600)).To(Succeed())
+		Expect(sink.Write(audit.Record{Repository: "owner/repo", PullRequest: 1})).To(Succeed())
+
+		// when
+		records, err := sink.Recent("owner/repo", 10)
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(records).To(HaveLen(1))
+	})
+
+	It("should return records written after the previous query", func() {
+		// given
+		Expect(sink.Write(audit.Record{Repository: "owner/repo", PullRequest: 1})).To(Succeed())
+		_, err := sink.Recent("owner/repo", 10)
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(sink.Write(audit.Record{Repository: "owner/repo", PullRequest: 2})).To(Succeed())
+
+		// when
+		records, err := sink.Recent("owner/repo", 10)
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(records).To(HaveLen(2))
+		Expect(records[0].PullRequest).To(Equal(2))
+		Expect(records[1].PullRequest).To(Equal(1))
+	})
+
+	It("should start over when the file has been truncated", func() {
+		// given
+		Expect(sink.Write(audit.Record{Repository: "owner/repo", PullRequest: 1})).To(Succeed())
+		Expect(sink.Write(audit.Record{Repository: "owner/repo", PullRequest: 2})).To(Succeed())
+		_, err := sink.Recent("owner/repo", 10)
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(os.Remove(sink.Path)).To(Succeed())
+		Expect(sink.Write(audit.Record{Repository: "owner/repo", PullRequest: 3})).To(Succeed())
+
+		// when
+		records, err := sink.Recent("owner/repo", 10)
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].PullRequest).To(Equal(3))
+	})
+})
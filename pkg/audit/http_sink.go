@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPSink sends audit records as JSON payloads using POST requests to the given endpoint
+type HTTPSink struct {
+	Endpoint string
+	Client   *http.Client
+}
+
+// NewHTTPSink creates an instance of HTTPSink sending the records to the given endpoint
+func NewHTTPSink(endpoint string) *HTTPSink {
+	return &HTTPSink{
+		Endpoint: endpoint,
+		Client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Write sends the given record to the endpoint
+func (s *HTTPSink) Write(record Record) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.Client.Post(s.Endpoint, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit endpoint %s responded with status code %d", s.Endpoint, resp.StatusCode)
+	}
+	return nil
+}
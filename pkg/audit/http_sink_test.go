@@ -0,0 +1,52 @@
+package audit_test
+
+import (
+	"github.com/arquillian/ike-prow-plugins/pkg/audit"
+	. "github.com/arquillian/ike-prow-plugins/pkg/internal/test"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+var _ = Describe("HTTP sink features", func() {
+
+	BeforeEach(func() {
+		gock.Off()
+	})
+
+	AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+	It("should send the record as a JSON payload", func() {
+		// given
+		gock.New("http://audit.example.com").
+			Post("/records").
+			MatchType("json").
+			JSON(map[string]interface{}{"repository": "owner/repo", "pull_request": 1, "command": "/run",
+				"action": "created", "user": "alien", "outcome": "accepted", "time": "0001-01-01T00:00:00Z"}).
+			Reply(201)
+		sink := audit.NewHTTPSink("http://audit.example.com/records")
+
+		// when
+		err := sink.Write(audit.Record{Repository: "owner/repo", PullRequest: 1, Command: "/run", Action: "created",
+			User: "alien", Outcome: audit.Accepted})
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(gock.IsDone()).To(BeTrue())
+	})
+
+	It("should fail when the endpoint responds with an error", func() {
+		// given
+		gock.New("http://audit.example.com").
+			Post("/records").
+			Reply(500)
+		sink := audit.NewHTTPSink("http://audit.example.com/records")
+
+		// when
+		err := sink.Write(audit.Record{Repository: "owner/repo"})
+
+		// then
+		Ω(err).Should(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("status code 500"))
+	})
+})
@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+)
+
+const (
+	// DefaultLimit is a number of records returned by the query handler when no limit is specified
+	DefaultLimit = 50
+	// MaxLimit is the maximal number of records returned by the query handler
+	MaxLimit = 1000
+)
+
+// NewQueryHandler creates a http.Handler listing recent audit records of a repository provided by the given Querier.
+// The repository is specified by "repository" query parameter (in "owner/name" format) and the number
+// of records by optional "limit" parameter, e.g. /audit?repository=arquillian/ike-prow-plugins&limit=10
+// Every request has to be authorized by the given token sent in "Authorization: Bearer <token>" header
+func NewQueryHandler(querier Querier, token []byte, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isAuthorized(r, token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "missing or invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			http.Error(w, "only GET method is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		repository := r.URL.Query().Get("repository")
+		if repository == "" {
+			http.Error(w, "missing repository query parameter (e.g. ?repository=owner/name)", http.StatusBadRequest)
+			return
+		}
+
+		limit, err := parseLimit(r.URL.Query().Get("limit"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		records, err := querier.Recent(repository, limit)
+		if err != nil {
+			logger.Errorf("failed to load audit records for repository %s. cause: %s", repository, err)
+			http.Error(w, "failed to load audit records", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(records); err != nil {
+			logger.Errorf("failed to write audit records for repository %s. cause: %s", repository, err)
+		}
+	})
+}
+
+func isAuthorized(r *http.Request, token []byte) bool {
+	if len(token) == 0 {
+		return false
+	}
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return false
+	}
+	provided := strings.TrimPrefix(header, "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(provided), token) == 1
+}
+
+func parseLimit(value string) (int, error) {
+	if value == "" {
+		return DefaultLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("limit has to be a positive number, but was %q", value)
+	}
+	if limit > MaxLimit {
+		return MaxLimit, nil
+	}
+	return limit, nil
+}
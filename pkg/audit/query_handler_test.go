@@ -0,0 +1,118 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/audit"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type querierStub struct {
+	repository string
+	limit      int
+	records    []audit.Record
+}
+
+func (q *querierStub) Recent(repository string, limit int) ([]audit.Record, error) {
+	q.repository = repository
+	q.limit = limit
+	return q.records, nil
+}
+
+var _ = Describe("Audit query handler features", func() {
+
+	var (
+		querier *querierStub
+		handler http.Handler
+	)
+
+	BeforeEach(func() {
+		querier = &querierStub{records: []audit.Record{{Repository: "owner/repo", Command: "/ok-without-tests", User: "alien"}}}
+		handler = audit.NewQueryHandler(querier, []byte("s3cr3t"), log.NewTestLogger())
+	})
+
+	authorized := func(request *http.Request) *http.Request {
+		request.Header.Set("Authorization", "Bearer s3cr3t")
+		return request
+	}
+
+	It("should reject request without token", func() {
+		// given
+		recorder := httptest.NewRecorder()
+
+		// when
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/audit?repository=owner/repo", nil))
+
+		// then
+		Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+		Expect(querier.repository).To(BeEmpty())
+	})
+
+	It("should reject request with invalid token", func() {
+		// given
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/audit?repository=owner/repo", nil)
+		request.Header.Set("Authorization", "Bearer guessed")
+
+		// when
+		handler.ServeHTTP(recorder, request)
+
+		// then
+		Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+		Expect(querier.repository).To(BeEmpty())
+	})
+
+	It("should list records of the given repository", func() {
+		// given
+		recorder := httptest.NewRecorder()
+
+		// when
+		handler.ServeHTTP(recorder, authorized(httptest.NewRequest(http.MethodGet, "/audit?repository=owner/repo&limit=10", nil)))
+
+		// then
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		var records []audit.Record
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &records)).To(Succeed())
+		Expect(records).To(Equal(querier.records))
+		Expect(querier.repository).To(Equal("owner/repo"))
+		Expect(querier.limit).To(Equal(10))
+	})
+
+	It("should use default limit when none is specified", func() {
+		// given
+		recorder := httptest.NewRecorder()
+
+		// when
+		handler.ServeHTTP(recorder, authorized(httptest.NewRequest(http.MethodGet, "/audit?repository=owner/repo", nil)))
+
+		// then
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(querier.limit).To(Equal(audit.DefaultLimit))
+	})
+
+	It("should reject request without repository", func() {
+		// given
+		recorder := httptest.NewRecorder()
+
+		// when
+		handler.ServeHTTP(recorder, authorized(httptest.NewRequest(http.MethodGet, "/audit", nil)))
+
+		// then
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should reject request with invalid limit", func() {
+		// given
+		recorder := httptest.NewRecorder()
+
+		// when
+		handler.ServeHTTP(recorder, authorized(httptest.NewRequest(http.MethodGet, "/audit?repository=owner/repo&limit=-1", nil)))
+
+		// then
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+	})
+})
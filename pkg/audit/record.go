@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"time"
+)
+
+// These are possible outcomes of a command execution stored in a Record
+const (
+	// Accepted means that the user was allowed to trigger the command and it has been performed
+	Accepted = "accepted"
+	// Failed means that the user was allowed to trigger the command but it failed while being performed
+	Failed = "failed"
+	// Rejected means that the user was not allowed to trigger the command
+	Rejected = "rejected"
+	// Invalid means that the command couldn't be parsed so it hasn't been performed
+	Invalid = "invalid"
+)
+
+// Record holds information about a command triggered in a pull request comment
+type Record struct {
+	Time          time.Time `json:"time"`
+	Plugin        string    `json:"plugin,omitempty"`
+	Repository    string    `json:"repository"`
+	PullRequest   int       `json:"pull_request"`
+	HeadSHA       string    `json:"head_sha,omitempty"`
+	Source        string    `json:"source,omitempty"`
+	Command       string    `json:"command"`
+	Arguments     []string  `json:"arguments,omitempty"`
+	Action        string    `json:"action"`
+	User          string    `json:"user"`
+	Outcome       string    `json:"outcome"`
+	ApprovedRoles []string  `json:"approved_roles,omitempty"`
+	RejectedRoles []string  `json:"rejected_roles,omitempty"`
+	Error         string    `json:"error,omitempty"`
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+)
+
+// Sink stores audit records
+type Sink interface {
+	Write(record Record) error
+}
+
+// Querier provides audit records that have been already stored
+type Querier interface {
+	// Recent returns at most limit of the latest records for the given repository (in "owner/name" format) - the newest first
+	Recent(repository string, limit int) ([]Record, error)
+}
+
+// MultiSink writes the records to all the contained sinks
+type MultiSink []Sink
+
+// Write writes the given record to all the contained sinks. The first occurred error is returned
+func (s MultiSink) Write(record Record) error {
+	var firstErr error
+	for _, sink := range s {
+		if err := sink.Write(record); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+var (
+	registeredSink   Sink
+	registeredPlugin string
+	lock             sync.RWMutex
+)
+
+// Register sets the sink all audit records emitted by the given plugin are written to. When the sink is nil, then
+// the audit is disabled (default)
+func Register(pluginName string, sink Sink) {
+	lock.Lock()
+	defer lock.Unlock()
+	registeredPlugin = pluginName
+	registeredSink = sink
+}
+
+// Enabled says if there is a sink registered
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return registeredSink != nil
+}
+
+// Emit writes the given record to the registered sink (if there is any). As the audit shouldn't affect the command
+// processing, a failure is just logged
+func Emit(logger log.Logger, record Record) {
+	lock.RLock()
+	sink, plugin := registeredSink, registeredPlugin
+	lock.RUnlock()
+
+	if sink == nil {
+		return
+	}
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+	record.Plugin = plugin
+	if err := sink.Write(record); err != nil {
+		logger.Errorf("failed to write audit record [%+v]. cause: %s", record, err)
+	}
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/audit"
+	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
+	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	gogh "github.com/google/go-github/v41/github"
+)
+
+type cmdAuditor struct {
+	command  string
+	client   ghclient.Client
+	logger   log.Logger
+	source   *CmdSource
+	prLoader *ghservice.PullRequestLazyLoader
+}
+
+func (e *CmdExecutor) newAuditor(client ghclient.Client, logger log.Logger, source *CmdSource) *cmdAuditor {
+	return &cmdAuditor{command: e.Command, client: client, logger: logger, source: source, prLoader: e.PullRequest}
+}
+
+// record emits an audit record of the command with the given outcome. The pull request is loaded only when the audit is enabled
+func (a *cmdAuditor) record(outcome string, status *PermissionStatus, cause error) {
+	if !audit.Enabled() {
+		return
+	}
+
+	record := audit.Record{
+		Repository:  a.source.Issue.Owner + "/" + a.source.Issue.RepoName,
+		PullRequest: a.source.Issue.Number,
+		Source:      string(a.source.Kind),
+		Command:     a.command,
+		Arguments:   arguments(a.source.CommandLine()),
+		Action:      a.source.Action,
+		User:        a.source.Author,
+		Outcome:     outcome,
+	}
+	if status != nil {
+		record.ApprovedRoles = status.ApprovedRoles
+		record.RejectedRoles = status.RejectedRoles
+	}
+	if cause != nil {
+		record.Error = cause.Error()
+	}
+	if pullRequest, err := a.loadPullRequest(); err != nil {
+		a.logger.Warnf("failed to load pull request so the head SHA is not part of the audit record. cause: %s", err)
+	} else {
+		record.HeadSHA = pullRequest.GetHead().GetSHA()
+	}
+
+	audit.Emit(a.logger, record)
+}
+
+func (a *cmdAuditor) loadPullRequest() (*gogh.PullRequest, error) {
+	if a.prLoader == nil {
+		a.prLoader = a.source.PullRequestLoader(a.client)
+	}
+	return a.prLoader.Load()
+}
+
+func arguments(body string) []string {
+	fields := strings.Fields(body)
+	if len(fields) < 2 {
+		return nil
+	}
+	return fields[1:]
+}
@@ -1,25 +1,41 @@
 package command
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/arquillian/ike-prow-plugins/pkg/audit"
+	"github.com/arquillian/ike-prow-plugins/pkg/github"
 	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
 	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
 	"github.com/arquillian/ike-prow-plugins/pkg/log"
 	"github.com/arquillian/ike-prow-plugins/pkg/utils"
-	gogh "github.com/google/go-github/v41/github"
 )
 
+// InvalidArgumentsMessage is a message used in a comment when a command was triggered with arguments it doesn't accept
+const InvalidArgumentsMessage = "Hey @%s! It seems you tried to trigger `%s` command but it couldn't be processed: %s"
+
 // DoFunction is used for performing operations related to command actions
 type DoFunction func() error
-type doFunctionExecutor func(client ghclient.Client, logger log.Logger, comment *gogh.IssueCommentEvent) error
 
-// CmdExecutor takes care of executing a command triggered by IssueCommentEvent.
+// ArgumentsValidator checks the arguments the command was triggered with (the words following the command itself)
+type ArgumentsValidator func(arguments []string) error
+
+type doFunctionExecutor func(client ghclient.Client, logger log.Logger, source *CmdSource, feedback Feedback) error
+
+// CmdExecutor takes care of executing a command triggered by a CmdSource (comment, review or review comment).
 // The execution is set by specifying actions/events and with given restrictions the command should be triggered for.
+// Depending on the Feedback the triggering comment is acknowledged by reactions - eyes when the command is accepted,
+// +1 when it's completed, -1 when the user is not allowed to trigger it and confused when it fails or can't be parsed.
+// Every accepted or rejected command is recorded in the audit (if enabled). The PullRequest loader is used to retrieve
+// the head SHA the command was triggered for - when not set, a new one is created from the comment.
 type CmdExecutor struct {
-	Command   string
-	Quiet     bool
-	executors []doFunctionExecutor
+	Command           string
+	Quiet             bool
+	Feedback          FeedbackLoader
+	ValidateArguments ArgumentsValidator
+	PullRequest       *ghservice.PullRequestLazyLoader
+	executors         []doFunctionExecutor
 }
 
 // RestrictionSetter keeps information about set actions the command should be triggered for and opens an API to provide
@@ -40,16 +56,17 @@ type commentAction struct {
 	actions     []string
 	description string
 	log         bool
+	acknowledge bool
 }
 
 // Deleted represents comment deletion
-var Deleted = commentAction{actions: []string{"deleted"}, description: "delete", log: false}
+var Deleted = commentAction{actions: []string{"deleted"}, description: "delete", log: false, acknowledge: false}
 
-// Triggered represents comment editions and creation
-var Triggered = commentAction{actions: []string{"edited", "created"}, description: "trigger", log: true}
+// Triggered represents comment editions and creation (or review submission)
+var Triggered = commentAction{actions: []string{"edited", "created", "submitted"}, description: "trigger", log: true, acknowledge: true}
 
-func (a *commentAction) isMatching(comment *gogh.IssueCommentEvent) bool {
-	return utils.Contains(a.actions, *comment.Action)
+func (a *commentAction) isMatching(source *CmdSource) bool {
+	return utils.Contains(a.actions, source.Action)
 }
 
 // When takes list of actions the command should be triggered for
@@ -64,21 +81,37 @@ func (s *RestrictionSetter) By(permissionChecks ...PermissionCheck) *DoFunctionP
 
 // Then take a DoFunction that performs the required operations (when all checks are fulfilled)
 func (p *DoFunctionProvider) Then(doFunction DoFunction) {
-	doExecutor := func(client ghclient.Client, logger log.Logger, comment *gogh.IssueCommentEvent) error {
-		matchingAction := p.getMatchingAction(comment)
+	doExecutor := func(client ghclient.Client, logger log.Logger, source *CmdSource, feedback Feedback) error {
+		matchingAction := p.getMatchingAction(source)
 		if matchingAction == nil {
 			return nil
 		}
 
+		reactions := newReactionService(client, logger, source, feedback.Reactions() && matchingAction.acknowledge)
+		auditor := p.commandExecutor.newAuditor(client, logger, source)
+
 		status, err := AllOf(p.permissionChecks...)(true)
 		if status.UserIsApproved && err == nil {
-			return doFunction()
+			reactions.react(github.ReactionEyes)
+			if err := doFunction(); err != nil {
+				reactions.react(github.ReactionConfused)
+				auditor.record(audit.Failed, status, err)
+				return err
+			}
+			reactions.react(github.ReactionThumbsUp)
+			auditor.record(audit.Accepted, status, nil)
+			return nil
+		}
+		auditor.record(audit.Rejected, status, err)
+		if err != nil {
+			reactions.react(github.ReactionConfused)
+		} else {
+			reactions.react(github.ReactionThumbsDown)
 		}
 		message := status.constructMessage(matchingAction.description, p.commandExecutor.Command)
 		logger.Warn(message)
-		if err == nil && matchingAction.log && !p.commandExecutor.Quiet {
-			commentService := ghservice.NewCommentService(client, comment)
-			return commentService.AddComment(&message)
+		if err == nil && matchingAction.log && !p.commandExecutor.Quiet && feedback.Comments() {
+			return source.CommentService(client).AddComment(&message)
 		}
 		return err
 	}
@@ -86,27 +119,46 @@ func (p *DoFunctionProvider) Then(doFunction DoFunction) {
 	p.commandExecutor.executors = append(p.commandExecutor.executors, doExecutor)
 }
 
-func (p *DoFunctionProvider) getMatchingAction(comment *gogh.IssueCommentEvent) *commentAction {
+func (p *DoFunctionProvider) getMatchingAction(source *CmdSource) *commentAction {
 	for _, action := range p.actions {
 		action := action
-		if action.isMatching(comment) {
+		if action.isMatching(source) {
 			return &action
 		}
 	}
 	return nil
 }
 
-// Execute triggers the given DoFunctions (when all checks are fulfilled) for the given pr comment
-func (e *CmdExecutor) Execute(client ghclient.Client, logger log.Logger, comment *gogh.IssueCommentEvent) error {
-	body := strings.TrimSpace(*comment.Comment.Body)
-	if prefix := strings.Split(body, " ")[0]; e.Command != body && prefix != e.Command {
+// Execute triggers the given DoFunctions (when all checks are fulfilled) for the given command source
+func (e *CmdExecutor) Execute(client ghclient.Client, logger log.Logger, source *CmdSource) error {
+	line := source.CommandLine()
+	if prefix := strings.Split(line, " ")[0]; e.Command != line && prefix != e.Command {
 		return nil
 	}
+	feedback := e.Feedback.load()
+	if Triggered.isMatching(source) && e.ValidateArguments != nil {
+		if err := e.ValidateArguments(arguments(line)); err != nil {
+			return e.reportInvalidArguments(client, logger, source, feedback, err)
+		}
+	}
 	for _, doExecutor := range e.executors {
-		err := doExecutor(client, logger, comment)
+		err := doExecutor(client, logger, source, feedback)
 		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+func (e *CmdExecutor) reportInvalidArguments(client ghclient.Client, logger log.Logger, source *CmdSource,
+	feedback Feedback, cause error) error {
+	newReactionService(client, logger, source, feedback.Reactions()).react(github.ReactionConfused)
+	e.newAuditor(client, logger, source).record(audit.Invalid, nil, cause)
+
+	message := fmt.Sprintf(InvalidArgumentsMessage, source.Author, e.Command, cause)
+	logger.Warn(message)
+	if !e.Quiet && feedback.ExplicitComments() {
+		return source.CommentService(client).AddComment(&message)
+	}
+	return nil
+}
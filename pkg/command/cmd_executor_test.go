@@ -1,6 +1,9 @@
 package command_test
 
 import (
+	"fmt"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/audit"
 	is "github.com/arquillian/ike-prow-plugins/pkg/command"
 	. "github.com/arquillian/ike-prow-plugins/pkg/internal/test"
 	"github.com/arquillian/ike-prow-plugins/pkg/log"
@@ -54,7 +57,7 @@ var _ = Describe("Command executor features", func() {
 			})
 
 			// when
-			err := command.Execute(client, log, deletedCommand)
+			err := command.Execute(client, log, is.NewIssueCommentSource(deletedCommand))
 
 			// then
 			Ω(err).ShouldNot(HaveOccurred())
@@ -73,7 +76,7 @@ var _ = Describe("Command executor features", func() {
 			})
 
 			// when
-			err := command.Execute(client, log, deletedCommand)
+			err := command.Execute(client, log, is.NewIssueCommentSource(deletedCommand))
 
 			// then
 			Ω(err).ShouldNot(HaveOccurred())
@@ -93,7 +96,7 @@ var _ = Describe("Command executor features", func() {
 			})
 
 			// when
-			err := command.Execute(client, log, deletedCommand)
+			err := command.Execute(client, log, is.NewIssueCommentSource(deletedCommand))
 
 			// then
 			Ω(err).ShouldNot(HaveOccurred())
@@ -113,9 +116,9 @@ var _ = Describe("Command executor features", func() {
 			})
 
 			// when
-			err := command.Execute(client, log, deletedCommand)
+			err := command.Execute(client, log, is.NewIssueCommentSource(deletedCommand))
 			Ω(err).ShouldNot(HaveOccurred())
-			err = command.Execute(client, log, triggeredCommand)
+			err = command.Execute(client, log, is.NewIssueCommentSource(triggeredCommand))
 
 			// then
 			Ω(err).ShouldNot(HaveOccurred())
@@ -133,7 +136,7 @@ var _ = Describe("Command executor features", func() {
 			})
 
 			// when
-			err := command.Execute(client, log, deletedCommand)
+			err := command.Execute(client, log, is.NewIssueCommentSource(deletedCommand))
 
 			// then
 			Ω(err).ShouldNot(HaveOccurred())
@@ -150,7 +153,7 @@ var _ = Describe("Command executor features", func() {
 			})
 
 			// when
-			err := command.Execute(client, log, deletedCommand)
+			err := command.Execute(client, log, is.NewIssueCommentSource(deletedCommand))
 
 			// then
 			Ω(err).ShouldNot(HaveOccurred())
@@ -182,7 +185,7 @@ var _ = Describe("Command executor features", func() {
 				})
 
 				// when
-				err := command.Execute(client, log, triggeredCommand)
+				err := command.Execute(client, log, is.NewIssueCommentSource(triggeredCommand))
 
 				// then
 				Ω(err).ShouldNot(HaveOccurred())
@@ -199,7 +202,7 @@ var _ = Describe("Command executor features", func() {
 				})
 
 				// when
-				err := command.Execute(client, log, deletedCommand)
+				err := command.Execute(client, log, is.NewIssueCommentSource(deletedCommand))
 
 				// then
 				Ω(err).ShouldNot(HaveOccurred())
@@ -216,7 +219,7 @@ var _ = Describe("Command executor features", func() {
 				})
 
 				// when
-				err := command.Execute(client, log, deletedCommand)
+				err := command.Execute(client, log, is.NewIssueCommentSource(deletedCommand))
 
 				// then
 				Ω(err).ShouldNot(HaveOccurred())
@@ -233,7 +236,7 @@ var _ = Describe("Command executor features", func() {
 				})
 
 				// when
-				err := command.Execute(client, log, triggeredCommand)
+				err := command.Execute(client, log, is.NewIssueCommentSource(triggeredCommand))
 
 				// then
 				Ω(err).Should(HaveOccurred())
@@ -241,4 +244,274 @@ var _ = Describe("Command executor features", func() {
 			})
 		})
 	})
+
+	Context("Acknowledging commands with reactions", func() {
+
+		const commentID = int64(42)
+
+		BeforeEach(func() {
+			gock.OffAll()
+			triggeredCommand.Comment.ID = gogh.Int64(commentID)
+			triggeredCommand.Sender = &gogh.User{Login: utils.String("sender")}
+		})
+
+		AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+		feedback := func(feedback is.Feedback) is.FeedbackLoader {
+			return func() is.Feedback {
+				return feedback
+			}
+		}
+
+		It("should add eyes and +1 reactions when the command is accepted and completed", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				Expecting(
+					Reaction(commentID, "eyes"),
+					Reaction(commentID, "+1")).
+				Create()
+
+			triggeredCommand.Repo = mock.PullRequest.Base.Repo
+			command := is.CmdExecutor{Command: "/command", Feedback: feedback(is.FeedbackReactions)}
+			command.When(is.Triggered).By(is.Anybody).Then(func() error {
+				return nil
+			})
+
+			// when
+			err := command.Execute(client, log, is.NewIssueCommentSource(triggeredCommand))
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should add confused reaction when the command fails", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				Expecting(
+					Reaction(commentID, "eyes"),
+					Reaction(commentID, "confused")).
+				Create()
+
+			triggeredCommand.Repo = mock.PullRequest.Base.Repo
+			command := is.CmdExecutor{Command: "/command", Feedback: feedback(is.FeedbackReactions)}
+			command.When(is.Triggered).By(is.Anybody).Then(func() error {
+				return fmt.Errorf("failure")
+			})
+
+			// when
+			err := command.Execute(client, log, is.NewIssueCommentSource(triggeredCommand))
+
+			// then
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("should add -1 reaction without a comment when the user doesn't have permissions", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				WithUsers(ExternalUser("sender")).
+				Expecting(
+					Reaction(commentID, "-1"),
+					NoComment()).
+				Create()
+
+			triggeredCommand.Repo = mock.PullRequest.Base.Repo
+			executed := false
+			command := is.CmdExecutor{Command: "/command", Feedback: feedback(is.FeedbackReactions)}
+			command.When(is.Triggered).By(mock.PermissionForUser("sender").ThatIs().Admin).Then(func() error {
+				executed = true
+				return nil
+			})
+
+			// when
+			err := command.Execute(client, log, is.NewIssueCommentSource(triggeredCommand))
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(executed).To(BeFalse())
+		})
+
+		It("should add -1 reaction together with a comment when the user doesn't have permissions and all feedback is enabled", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				WithUsers(ExternalUser("sender")).
+				Expecting(
+					Reaction(commentID, "-1"),
+					Comment(To(HaveBodyThatContains("Hey @sender! It seems you tried to trigger `/command` command")))).
+				Create()
+
+			triggeredCommand.Repo = mock.PullRequest.Base.Repo
+			command := is.CmdExecutor{Command: "/command", Feedback: feedback(is.FeedbackAll)}
+			command.When(is.Triggered).By(mock.PermissionForUser("sender").ThatIs().Admin).Then(func() error {
+				return nil
+			})
+
+			// when
+			err := command.Execute(client, log, is.NewIssueCommentSource(triggeredCommand))
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should add confused reaction and not execute the command when its arguments are invalid", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				Expecting(
+					Reaction(commentID, "confused"),
+					NoComment()).
+				Create()
+
+			triggeredCommand.Repo = mock.PullRequest.Base.Repo
+			triggeredCommand.Comment.Body = utils.String("/command unexpected")
+			executed := false
+			command := is.CmdExecutor{Command: "/command", Feedback: feedback(is.FeedbackReactions),
+				ValidateArguments: func(arguments []string) error {
+					return fmt.Errorf("unexpected arguments %v", arguments)
+				}}
+			command.When(is.Triggered).By(is.Anybody).Then(func() error {
+				executed = true
+				return nil
+			})
+
+			// when
+			err := command.Execute(client, log, is.NewIssueCommentSource(triggeredCommand))
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(executed).To(BeFalse())
+		})
+
+		It("should neither react nor comment when the arguments are invalid and the default feedback is used", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				Expecting(
+					NoReaction(commentID),
+					NoComment()).
+				Create()
+
+			triggeredCommand.Repo = mock.PullRequest.Base.Repo
+			triggeredCommand.Comment.Body = utils.String("/command unexpected")
+			executed := false
+			command := is.CmdExecutor{Command: "/command",
+				ValidateArguments: func(arguments []string) error {
+					return fmt.Errorf("unexpected arguments %v", arguments)
+				}}
+			command.When(is.Triggered).By(is.Anybody).Then(func() error {
+				executed = true
+				return nil
+			})
+
+			// when
+			err := command.Execute(client, log, is.NewIssueCommentSource(triggeredCommand))
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(executed).To(BeFalse())
+		})
+
+		It("should not add any reaction when comments feedback is used", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				Expecting(NoReaction(commentID)).
+				Create()
+
+			triggeredCommand.Repo = mock.PullRequest.Base.Repo
+			executed := false
+			command := is.CmdExecutor{Command: "/command"}
+			command.When(is.Triggered).By(is.Anybody).Then(func() error {
+				executed = true
+				return nil
+			})
+
+			// when
+			err := command.Execute(client, log, is.NewIssueCommentSource(triggeredCommand))
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(executed).To(BeTrue())
+		})
+	})
+
+	Context("Auditing of triggered commands", func() {
+
+		var records *recordingSink
+
+		BeforeEach(func() {
+			gock.OffAll()
+			records = &recordingSink{}
+			audit.Register("plugin", records)
+		})
+
+		AfterEach(func() {
+			audit.Register("", nil)
+			EnsureGockRequestsHaveBeenMatched()
+		})
+
+		It("should record accepted command together with head SHA and arguments", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				WithUsers(Admin("sender")).
+				Create()
+
+			triggeredCommand.Repo = mock.PullRequest.Base.Repo
+			triggeredCommand.Sender = &gogh.User{Login: utils.String("sender")}
+			triggeredCommand.Comment.Body = utils.String("/command all")
+			command := is.CmdExecutor{Command: "/command"}
+			command.When(is.Triggered).By(mock.PermissionForUser("sender").ThatIs().Admin).Then(func() error {
+				return nil
+			})
+
+			// when
+			err := command.Execute(client, log, is.NewIssueCommentSource(triggeredCommand))
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(records.written).To(HaveLen(1))
+			record := records.written[0]
+			Expect(record.Plugin).To(Equal("plugin"))
+			Expect(record.Repository).To(Equal("bartoszmajsak/wfswarm-booster-pipeline-test"))
+			Expect(record.PullRequest).To(Equal(1))
+			Expect(record.HeadSHA).To(Equal("df8e5cd15f05e1d975e17df322b9babedccf0a1a"))
+			Expect(record.Command).To(Equal("/command"))
+			Expect(record.Arguments).To(ConsistOf("all"))
+			Expect(record.Action).To(Equal("created"))
+			Expect(record.User).To(Equal("sender"))
+			Expect(record.Outcome).To(Equal(audit.Accepted))
+			Expect(record.ApprovedRoles).To(ConsistOf(is.Admin))
+			Expect(record.Time).ToNot(BeZero())
+		})
+
+		It("should record rejected command", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				WithUsers(ExternalUser("sender")).
+				Expecting(Comment(To(HaveBodyThatContains("Hey @sender!")))).
+				Create()
+
+			triggeredCommand.Repo = mock.PullRequest.Base.Repo
+			triggeredCommand.Sender = &gogh.User{Login: utils.String("sender")}
+			command := is.CmdExecutor{Command: "/command"}
+			command.When(is.Triggered).By(mock.PermissionForUser("sender").ThatIs().Admin).Then(func() error {
+				return nil
+			})
+
+			// when
+			err := command.Execute(client, log, is.NewIssueCommentSource(triggeredCommand))
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(records.written).To(HaveLen(1))
+			Expect(records.written[0].Outcome).To(Equal(audit.Rejected))
+			Expect(records.written[0].User).To(Equal("sender"))
+			Expect(records.written[0].ApprovedRoles).To(ConsistOf(is.Admin))
+		})
+	})
 })
+
+type recordingSink struct {
+	written []audit.Record
+}
+
+func (s *recordingSink) Write(record audit.Record) error {
+	s.written = append(s.written, record)
+	return nil
+}
@@ -3,25 +3,31 @@ package command
 import (
 	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
 	"github.com/arquillian/ike-prow-plugins/pkg/log"
-	gogh "github.com/google/go-github/v41/github"
 )
 
-// CommentCmdHandler keeps list of CommentCmd implementations to be handled when an IssueCommentEvent occurs
+// CommentCmdHandler keeps list of CommentCmd implementations to be handled when a comment, review or review comment
+// containing a command occurs
 type CommentCmdHandler struct {
-	Client   ghclient.Client
-	commands []CommentCmd
+	Client     ghclient.Client
+	PluginName string
+	commands   []CommentCmd
 }
 
-// Register adds the given CommentCmd implementation to the list of commands to be handled when an IssueCommentEvent occurs
+// Register adds the given CommentCmd implementation to the list of commands to be handled when a CmdSource occurs
 func (s *CommentCmdHandler) Register(command CommentCmd) {
 	s.commands = append(s.commands, command)
 }
 
-// Handle triggers the process of evaluating and performing of all stored CommentCmd implementations for the given comment
-func (s *CommentCmdHandler) Handle(logger log.Logger, comment *gogh.IssueCommentEvent) error {
-	for _, commentCommand := range s.commands {
-		if commentCommand.Matches(comment) {
-			err := commentCommand.Perform(s.Client, logger, comment)
+// Handle triggers the process of evaluating and performing of all stored CommentCmd implementations for the given source.
+// Apart from the registered commands it also handles the built-in HelpCmd listing all of them
+func (s *CommentCmdHandler) Handle(logger log.Logger, source *CmdSource) error {
+	commands := make([]CommentCmd, 0, len(s.commands)+1)
+	commands = append(commands, s.commands...)
+	commands = append(commands, &HelpCmd{PluginName: s.PluginName, Commands: s.commands})
+
+	for _, commentCommand := range commands {
+		if commentCommand.Matches(source) {
+			err := commentCommand.Perform(s.Client, logger, source)
 			if err != nil {
 				return err
 			}
@@ -30,10 +36,20 @@ func (s *CommentCmdHandler) Handle(logger log.Logger, comment *gogh.IssueComment
 	return nil
 }
 
-// CommentCmd is a abstraction of a command that is triggered by a comment
+// CommentCmd is a abstraction of a command that is triggered by a comment (or a review or a review comment)
 type CommentCmd interface {
-	// Perform triggers the process of evaluating and performing of the command for the given comment
-	Perform(client ghclient.Client, logger log.Logger, comment *gogh.IssueCommentEvent) error
-	// Matches says if the content of the given comment matches the command
-	Matches(comment *gogh.IssueCommentEvent) bool
+	// Perform triggers the process of evaluating and performing of the command for the given source
+	Perform(client ghclient.Client, logger log.Logger, source *CmdSource) error
+	// Matches says if the content of the given source matches the command
+	Matches(source *CmdSource) bool
+	// Description provides the usage and the description of the command that is listed by the /help command
+	Description() CmdDescription
+	// WhoCanTrigger returns the permission check a user has to fulfill in order to trigger the command
+	WhoCanTrigger() PermissionCheck
+}
+
+// CmdDescription holds the usage and the description of a CommentCmd
+type CmdDescription struct {
+	Usage       string
+	Description string
 }
@@ -19,7 +19,7 @@ type configurableCommentCommand struct {
 	triggered         *bool
 }
 
-func (c *configurableCommentCommand) Perform(client ghclient.Client, logger log.Logger, comment *gogh.IssueCommentEvent) error {
+func (c *configurableCommentCommand) Perform(client ghclient.Client, logger log.Logger, source *is.CmdSource) error {
 	*c.triggered = true
 	if c.shouldReturnError {
 		return errors.New("error")
@@ -27,10 +27,18 @@ func (c *configurableCommentCommand) Perform(client ghclient.Client, logger log.
 	return nil
 }
 
-func (c *configurableCommentCommand) Matches(comment *gogh.IssueCommentEvent) bool {
+func (c *configurableCommentCommand) Matches(source *is.CmdSource) bool {
 	return c.shouldMatch
 }
 
+func (c *configurableCommentCommand) Description() is.CmdDescription {
+	return is.CmdDescription{Usage: "/command", Description: "configurable command"}
+}
+
+func (c *configurableCommentCommand) WhoCanTrigger() is.PermissionCheck {
+	return is.Anybody
+}
+
 var _ = Describe("Command handler features", func() {
 
 	client := NewDefaultGitHubClient()
@@ -56,7 +64,7 @@ var _ = Describe("Command handler features", func() {
 			commandHandler.Register(secondCommand)
 
 			// when
-			err := commandHandler.Handle(log, commentEvent)
+			err := commandHandler.Handle(log, is.NewIssueCommentSource(commentEvent))
 
 			// then
 			Ω(err).ShouldNot(HaveOccurred())
@@ -76,7 +84,7 @@ var _ = Describe("Command handler features", func() {
 			commandHandler.Register(secondCommand)
 
 			// when
-			err := commandHandler.Handle(log, commentEvent)
+			err := commandHandler.Handle(log, is.NewIssueCommentSource(commentEvent))
 
 			// then
 			Ω(err).Should(HaveOccurred())
@@ -96,7 +104,7 @@ var _ = Describe("Command handler features", func() {
 			commandHandler.Register(secondCommand)
 
 			// when
-			err := commandHandler.Handle(log, commentEvent)
+			err := commandHandler.Handle(log, is.NewIssueCommentSource(commentEvent))
 
 			// then
 			Ω(err).ShouldNot(HaveOccurred())
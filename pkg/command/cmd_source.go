@@ -0,0 +1,148 @@
+package command
+
+import (
+	"strings"
+	"time"
+
+	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
+	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
+	"github.com/arquillian/ike-prow-plugins/pkg/scm"
+	gogh "github.com/google/go-github/v41/github"
+)
+
+// SourceKind represents a kind of content a command can be written in
+type SourceKind string
+
+// These are possible kinds of CmdSource
+const (
+	// IssueCommentSource is a comment added to the pull request conversation
+	IssueCommentSource SourceKind = "issue_comment"
+	// ReviewSource is a body of a submitted pull request review
+	ReviewSource SourceKind = "review"
+	// ReviewCommentSource is an inline comment added to the pull request diff
+	ReviewCommentSource SourceKind = "review_comment"
+)
+
+// CmdSource is an event-agnostic representation of a content that can contain a command - be it an issue comment,
+// a body of a pull request review or an inline review comment. UpdatedAt holds the time the content was submitted
+// or last edited
+type CmdSource struct {
+	Kind      SourceKind
+	ID        int64
+	Author    string
+	Body      string
+	Action    string
+	Issue     scm.RepositoryIssue
+	UpdatedAt time.Time
+}
+
+// NewIssueCommentSource creates a CmdSource with information retrieved from the given IssueCommentEvent
+func NewIssueCommentSource(event *gogh.IssueCommentEvent) *CmdSource {
+	return &CmdSource{
+		Kind:      IssueCommentSource,
+		ID:        event.GetComment().GetID(),
+		Author:    event.GetSender().GetLogin(),
+		Body:      event.GetComment().GetBody(),
+		Action:    event.GetAction(),
+		Issue:     newRepositoryIssue(event.GetRepo(), event.GetIssue().GetNumber()),
+		UpdatedAt: commentTime(event.GetComment().GetCreatedAt(), event.GetComment().GetUpdatedAt()),
+	}
+}
+
+// NewReviewSource creates a CmdSource with information retrieved from the given PullRequestReviewEvent
+func NewReviewSource(event *gogh.PullRequestReviewEvent) *CmdSource {
+	return &CmdSource{
+		Kind:      ReviewSource,
+		ID:        event.GetReview().GetID(),
+		Author:    event.GetSender().GetLogin(),
+		Body:      event.GetReview().GetBody(),
+		Action:    event.GetAction(),
+		Issue:     newRepositoryIssue(event.GetRepo(), event.GetPullRequest().GetNumber()),
+		UpdatedAt: event.GetReview().GetSubmittedAt(),
+	}
+}
+
+// NewReviewCommentSource creates a CmdSource with information retrieved from the given PullRequestReviewCommentEvent
+func NewReviewCommentSource(event *gogh.PullRequestReviewCommentEvent) *CmdSource {
+	return &CmdSource{
+		Kind:      ReviewCommentSource,
+		ID:        event.GetComment().GetID(),
+		Author:    event.GetSender().GetLogin(),
+		Body:      event.GetComment().GetBody(),
+		Action:    event.GetAction(),
+		Issue:     newRepositoryIssue(event.GetRepo(), event.GetPullRequest().GetNumber()),
+		UpdatedAt: commentTime(event.GetComment().GetCreatedAt(), event.GetComment().GetUpdatedAt()),
+	}
+}
+
+// NewExistingIssueCommentSource creates a CmdSource representing an already existing comment of the given issue
+func NewExistingIssueCommentSource(issue scm.RepositoryIssue, comment *gogh.IssueComment) *CmdSource {
+	return &CmdSource{
+		Kind:      IssueCommentSource,
+		ID:        comment.GetID(),
+		Author:    comment.GetUser().GetLogin(),
+		Body:      comment.GetBody(),
+		Action:    "created",
+		Issue:     issue,
+		UpdatedAt: commentTime(comment.GetCreatedAt(), comment.GetUpdatedAt()),
+	}
+}
+
+// NewExistingReviewSource creates a CmdSource representing an already submitted review of the given pull request
+func NewExistingReviewSource(issue scm.RepositoryIssue, review *gogh.PullRequestReview) *CmdSource {
+	return &CmdSource{
+		Kind:      ReviewSource,
+		ID:        review.GetID(),
+		Author:    review.GetUser().GetLogin(),
+		Body:      review.GetBody(),
+		Action:    "submitted",
+		Issue:     issue,
+		UpdatedAt: review.GetSubmittedAt(),
+	}
+}
+
+// PullRequestLoader creates a PullRequestLazyLoader for the pull request the source belongs to
+func (s *CmdSource) PullRequestLoader(client ghclient.Client) *ghservice.PullRequestLazyLoader {
+	return &ghservice.PullRequestLazyLoader{
+		Client:    client,
+		RepoOwner: s.Issue.Owner,
+		RepoName:  s.Issue.RepoName,
+		Number:    s.Issue.Number,
+	}
+}
+
+// CommentService creates a CommentService adding comments to the pull request the source belongs to
+func (s *CmdSource) CommentService(client ghclient.Client) *ghservice.CommentService {
+	return &ghservice.CommentService{Client: client, Issue: s.Issue}
+}
+
+func newRepositoryIssue(repo *gogh.Repository, number int) scm.RepositoryIssue {
+	return scm.RepositoryIssue{
+		Owner:    repo.GetOwner().GetLogin(),
+		RepoName: repo.GetName(),
+		Number:   number,
+	}
+}
+
+func commentTime(createdAt, updatedAt time.Time) time.Time {
+	if updatedAt.After(createdAt) {
+		return updatedAt
+	}
+	return createdAt
+}
+
+// CommandLine returns the first non-empty line of the body which is expected to contain the command and its arguments.
+// The words of the line are separated by a single space. The rest of the body (e.g. review summary) is ignored
+func (s *CmdSource) CommandLine() string {
+	for _, line := range strings.Split(s.Body, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			return strings.Join(fields, " ")
+		}
+	}
+	return ""
+}
+
+// Arguments returns the words following the command in the command line
+func (s *CmdSource) Arguments() []string {
+	return arguments(s.CommandLine())
+}
@@ -0,0 +1,84 @@
+package command_test
+
+import (
+	is "github.com/arquillian/ike-prow-plugins/pkg/command"
+	. "github.com/arquillian/ike-prow-plugins/pkg/internal/test"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Command source features", func() {
+
+	Context("Creating command source from events", func() {
+
+		var mock *PrMock
+
+		BeforeEach(func() {
+			mock = MockPr().LoadedFromDefaultStruct().Create()
+		})
+
+		It("should create source of a review with the pull request as the commented issue", func() {
+			// given
+			event := mock.CreateReviewEvent(SentBy("reviewer"), "/run all", "submitted")
+
+			// when
+			source := is.NewReviewSource(event)
+
+			// then
+			Ω(source.Kind).Should(Equal(is.ReviewSource))
+			Ω(source.Author).Should(Equal("reviewer"))
+			Ω(source.Action).Should(Equal("submitted"))
+			Ω(source.Issue.Number).Should(Equal(*mock.PullRequest.Number))
+			Ω(source.Issue.Owner).Should(Equal(*mock.PullRequest.Base.Repo.Owner.Login))
+		})
+
+		It("should create source of a review comment with the pull request as the commented issue", func() {
+			// given
+			event := mock.CreateReviewCommentEvent(SentBy("reviewer"), "/run all", "created")
+
+			// when
+			source := is.NewReviewCommentSource(event)
+
+			// then
+			Ω(source.Kind).Should(Equal(is.ReviewCommentSource))
+			Ω(source.Author).Should(Equal("reviewer"))
+			Ω(source.Issue.Number).Should(Equal(*mock.PullRequest.Number))
+		})
+	})
+
+	Context("Extracting command line", func() {
+
+		It("should take first non-empty line of a review body", func() {
+			// given
+			source := &is.CmdSource{Body: "\n  /ok-without-tests  \r\n\nOnly documentation has been changed"}
+
+			// when
+			line := source.CommandLine()
+
+			// then
+			Ω(line).Should(Equal("/ok-without-tests"))
+		})
+
+		It("should separate words of the command line by a single space", func() {
+			// given
+			source := &is.CmdSource{Body: "/test-keeper \t  explain"}
+
+			// when
+			line := source.CommandLine()
+
+			// then
+			Ω(line).Should(Equal("/test-keeper explain"))
+		})
+
+		It("should return empty command line for blank body", func() {
+			// given
+			source := &is.CmdSource{Body: " \n \n"}
+
+			// when
+			line := source.CommandLine()
+
+			// then
+			Ω(line).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,125 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+
+	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
+	"github.com/arquillian/ike-prow-plugins/pkg/scm"
+	"github.com/arquillian/ike-prow-plugins/pkg/utils"
+)
+
+const directorySeparator = "/"
+
+// CodeOwnersLocations contains paths the CODEOWNERS file is looked up at (in that order)
+var CodeOwnersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeOwners holds the rules parsed from a CODEOWNERS file
+type CodeOwners struct {
+	rules []codeOwnersRule
+}
+
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// ParseCodeOwners parses the given content of a CODEOWNERS file. Lines that are empty, comments or contain
+// a pattern that can't be compiled are ignored
+func ParseCodeOwners(content string) *CodeOwners {
+	codeOwners := &CodeOwners{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		pattern, err := regexp.Compile(codeOwnersPatternToRegexp(fields[0]))
+		if err != nil {
+			continue
+		}
+		owners := make([]string, 0, len(fields)-1)
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			owners = append(owners, owner)
+		}
+		codeOwners.rules = append(codeOwners.rules, codeOwnersRule{pattern: pattern, owners: owners})
+	}
+	return codeOwners
+}
+
+// Owners returns owners of the given path. As in GitHub, the last matching rule takes precedence
+func (c *CodeOwners) Owners(path string) []string {
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].pattern.MatchString(path) {
+			return c.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// codeOwnersPatternToRegexp transforms a gitignore-like pattern used in CODEOWNERS files to a regular expression.
+// A pattern that starts with "/" or contains "/" in the middle is anchored to the root of the repository,
+// otherwise it matches at any level. A pattern matching a directory matches also all files located in it
+func codeOwnersPatternToRegexp(pattern string) string {
+	onlyDirectory := strings.HasSuffix(pattern, directorySeparator)
+	trimmed := strings.Trim(pattern, directorySeparator)
+	anchored := strings.HasPrefix(pattern, directorySeparator) || strings.Contains(trimmed, directorySeparator)
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		switch {
+		case strings.HasPrefix(trimmed[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(trimmed[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case trimmed[i] == '*':
+			expr.WriteString("[^/]*")
+		case trimmed[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(trimmed[i])))
+		}
+	}
+
+	switch {
+	case onlyDirectory:
+		expr.WriteString("/.*$")
+	case strings.HasSuffix(trimmed, "/*"):
+		// as in GitHub, "docs/*" matches only files directly located in docs directory
+		expr.WriteString("$")
+	default:
+		expr.WriteString("(?:/.*)?$")
+	}
+	return expr.String()
+}
+
+// loadCodeOwners loads the first CODEOWNERS file found at any of the CodeOwnersLocations. Returns nil when there is
+// no such file and an error when any of the locations can't be checked - the file found at the next location
+// might not be the one GitHub uses
+func loadCodeOwners(change scm.RepositoryChange) (*CodeOwners, error) {
+	rawFileService := ghservice.RawFileService{Change: change}
+	for _, location := range CodeOwnersLocations {
+		content, err := utils.GetFileFromURL(rawFileService.GetRawFileURL(location))
+		switch {
+		case err == nil:
+			return ParseCodeOwners(string(content)), nil
+		case !utils.IsNotFound(err):
+			return nil, fmt.Errorf("failed to load %s file. cause: %s", location, err)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,51 @@
+package command_test
+
+import (
+	is "github.com/arquillian/ike-prow-plugins/pkg/command"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CODEOWNERS parsing features", func() {
+
+	codeOwners := is.ParseCodeOwners(`
+# default owners
+*                   @global-owner
+
+*.js                @js-owner # inline comment
+/build/logs/        @doctocat
+docs/*              docs@example.com
+apps/               @octocat
+/scripts/**/test/   @org/testers
+**/vendor           @org/vendor-team
+/src/module/        @module-owner @org/module-team
+`)
+
+	DescribeTable("should find owners of the given path respecting that the last matching rule wins",
+		func(path string, expectedOwners ...string) {
+			Expect(codeOwners.Owners(path)).To(ConsistOf(expectedOwners))
+		},
+		Entry("any file", "README.md", "@global-owner"),
+		Entry("js file in the root", "index.js", "@js-owner"),
+		Entry("js file in a subdirectory", "web/app/index.js", "@js-owner"),
+		Entry("file in an anchored directory", "build/logs/out.log", "@doctocat"),
+		Entry("file in a nested directory that is not anchored", "nested/build/logs/out.log", "@global-owner"),
+		Entry("file directly in docs directory", "docs/getting_started.md", "docs@example.com"),
+		Entry("file in docs subdirectory", "docs/chapters/intro.md", "@global-owner"),
+		Entry("file in apps directory at any level", "nested/apps/main.go", "@octocat"),
+		Entry("file in a test directory at any level of scripts", "scripts/a/b/test/run.sh", "@org/testers"),
+		Entry("file in a vendor directory at any level", "lib/vendor/dep/dep.go", "@org/vendor-team"),
+		Entry("file owned by multiple owners", "src/module/main.go", "@module-owner", "@org/module-team"),
+	)
+
+	It("should return no owners when there is no matching rule", func() {
+		// given
+		codeOwners := is.ParseCodeOwners("/docs/ @doc-owner")
+
+		// when
+		owners := codeOwners.Owners("pkg/main.go")
+
+		// then
+		Expect(owners).To(BeEmpty())
+	})
+})
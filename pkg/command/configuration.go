@@ -0,0 +1,74 @@
+package command
+
+import (
+	"github.com/arquillian/ike-prow-plugins/pkg/config"
+	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	"github.com/arquillian/ike-prow-plugins/pkg/scm"
+)
+
+// Configuration holds the part of a plugin configuration defining who is allowed to trigger the commands of the plugin
+// and how the users are informed about their processing. Plugins embed it inline in their configuration
+type Configuration struct {
+	Commands        CommandPermissions `yaml:"commands,omitempty"`
+	CommandFeedback Feedback           `yaml:"command_feedback,omitempty"`
+}
+
+// ConfigurationLoader lazily loads the Configuration of the commands of the plugin - only once and only when needed.
+// The configuration is loaded from the base commit of the pull request (as CODEOWNERS is) and not from its head, so the
+// author of the pull request can't grant themselves the permissions to trigger the commands by changing the configuration
+type ConfigurationLoader struct {
+	logger         log.Logger
+	pluginName     string
+	prLoader       *ghservice.PullRequestLazyLoader
+	pluginSpecific []interface{}
+	loaded         *Configuration
+}
+
+// NewConfigurationLoader creates a ConfigurationLoader of the commands of the given plugin for the pull request
+// loaded by the given loader. The plugin specific targets are unmarshaled from the same configuration file when
+// the configuration is loaded, so the plugin can read other settings from the base commit as well
+func NewConfigurationLoader(logger log.Logger, pluginName string, prLoader *ghservice.PullRequestLazyLoader,
+	pluginSpecific ...interface{}) *ConfigurationLoader {
+	return &ConfigurationLoader{logger: logger, pluginName: pluginName, prLoader: prLoader, pluginSpecific: pluginSpecific}
+}
+
+// Load loads the configuration (only once) so the plugin specific targets are populated and returns the Configuration
+// of the commands
+func (l *ConfigurationLoader) Load() *Configuration {
+	return l.load()
+}
+
+// Permissions returns the CommandPermissions configured at the base commit of the pull request. It can be used as PermissionsLoader
+func (l *ConfigurationLoader) Permissions() CommandPermissions {
+	return l.load().Commands
+}
+
+// Feedback returns the Feedback configured at the base commit of the pull request. It can be used as FeedbackLoader
+func (l *ConfigurationLoader) Feedback() Feedback {
+	return l.load().CommandFeedback
+}
+
+func (l *ConfigurationLoader) load() *Configuration {
+	if l.loaded != nil {
+		return l.loaded
+	}
+	l.loaded = &Configuration{}
+	pr, err := l.prLoader.Load()
+	if err != nil {
+		l.logger.Errorf("failed to load pull request so the default configuration of the commands is used. cause: %s", err)
+		return l.loaded
+	}
+	change := scm.RepositoryChange{Owner: l.prLoader.RepoOwner, RepoName: l.prLoader.RepoName, Hash: pr.GetBase().GetSHA()}
+	loadableConfig := &ghservice.LoadableConfig{PluginName: l.pluginName, Change: change, BaseConfig: &config.PluginConfiguration{}}
+	if err := config.LoadAll(loadableConfig, append([]interface{}{l.loaded}, l.pluginSpecific...)...); err != nil {
+		l.logger.Errorf("failed to load configuration of the commands so the default one is used. cause: %s", err)
+		l.loaded = &Configuration{}
+	}
+	if !l.loaded.CommandFeedback.IsValid() {
+		l.logger.Warnf("unknown command_feedback %q so the default feedback is used. expected one of: %s, %s, %s",
+			l.loaded.CommandFeedback, FeedbackComments, FeedbackReactions, FeedbackAll)
+		l.loaded.CommandFeedback = FeedbackDefault
+	}
+	return l.loaded
+}
@@ -0,0 +1,92 @@
+package command
+
+import (
+	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+)
+
+// Feedback defines how the users are informed about the processing of the commands they triggered.
+// It's unmarshaled from a plugin configuration file (command_feedback key)
+type Feedback string
+
+// These are possible Feedback modes
+const (
+	// FeedbackDefault is used when no feedback is configured. It adds a comment when the user is not allowed to trigger
+	// the command
+	FeedbackDefault Feedback = ""
+	// FeedbackComments adds a comment when the user is not allowed to trigger the command or when the command couldn't
+	// be processed
+	FeedbackComments Feedback = "comments"
+	// FeedbackReactions adds reactions to the triggering comment instead of adding new comments
+	FeedbackReactions Feedback = "reactions"
+	// FeedbackAll adds reactions to the triggering comment as well as comments when the user is not allowed to
+	// trigger the command or when the command couldn't be processed
+	FeedbackAll Feedback = "all"
+)
+
+// FeedbackLoader loads Feedback configured for the repository the command is triggered in
+type FeedbackLoader func() Feedback
+
+// Comments says if the feedback should be provided in a form of comments
+func (f Feedback) Comments() bool {
+	return f != FeedbackReactions
+}
+
+// ExplicitComments says if the comments are explicitly enabled in the configuration. Only then are the users
+// informed by a comment that the command they triggered couldn't be processed
+func (f Feedback) ExplicitComments() bool {
+	return f == FeedbackComments || f == FeedbackAll
+}
+
+// IsValid says if the feedback is one of the known modes
+func (f Feedback) IsValid() bool {
+	switch f {
+	case FeedbackDefault, FeedbackComments, FeedbackReactions, FeedbackAll:
+		return true
+	}
+	return false
+}
+
+// Reactions says if the feedback should be provided in a form of reactions to the triggering comment
+func (f Feedback) Reactions() bool {
+	return f == FeedbackReactions || f == FeedbackAll
+}
+
+func (l FeedbackLoader) load() Feedback {
+	if l == nil {
+		return FeedbackDefault
+	}
+	return l()
+}
+
+type reactionService struct {
+	client  ghclient.Client
+	source  *CmdSource
+	logger  log.Logger
+	enabled bool
+}
+
+func newReactionService(client ghclient.Client, logger log.Logger, source *CmdSource, enabled bool) *reactionService {
+	return &reactionService{client: client, source: source, logger: logger, enabled: enabled}
+}
+
+// react adds the given reaction to the source of the command. As the reactions are only informative, a failure is just logged.
+// GitHub doesn't support reactions to review bodies, so these are not acknowledged
+func (s *reactionService) react(reaction string) {
+	if !s.enabled {
+		return
+	}
+	commentService := s.source.CommentService(s.client)
+	var err error
+	switch s.source.Kind {
+	case IssueCommentSource:
+		err = commentService.AddReaction(s.source.ID, reaction)
+	case ReviewCommentSource:
+		err = commentService.AddReviewCommentReaction(s.source.ID, reaction)
+	default:
+		return
+	}
+	if err != nil {
+		s.logger.Errorf("failed to add reaction %q to the %s %d. cause: %s", reaction, s.source.Kind, s.source.ID, err)
+	}
+}
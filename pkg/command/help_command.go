@@ -0,0 +1,93 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	"github.com/arquillian/ike-prow-plugins/pkg/status/message"
+	"github.com/arquillian/ike-prow-plugins/pkg/utils"
+)
+
+// HelpCommentPrefix is used as a command prefix to list all commands available for a plugin
+const HelpCommentPrefix = "/help"
+
+// HelpCmd represents a command that is triggered by "/help", "/help plugin-name" or "/help all" and replies with
+// a table of commands (including the roles that are allowed to use them) registered for the plugin
+type HelpCmd struct {
+	PluginName string
+	Commands   []CommentCmd
+}
+
+// Perform adds a comment containing the table of the available commands for the given CmdSource
+func (c *HelpCmd) Perform(client ghclient.Client, logger log.Logger, source *CmdSource) error {
+	var HelpCommand = &CmdExecutor{Command: HelpCommentPrefix, Quiet: true}
+
+	HelpCommand.
+		When(Triggered).
+		By(c.WhoCanTrigger()).
+		Then(func() error {
+			helpMsg := c.constructMessage(source.Author)
+			return source.CommentService(client).AddComment(&helpMsg)
+		})
+
+	return HelpCommand.Execute(client, logger, source)
+}
+
+// Matches returns true when the given CmdSource content is "/help" or when its prefix is "/help" and it is
+// followed either by the plugin name or by "all"
+func (c *HelpCmd) Matches(source *CmdSource) bool {
+	command := strings.Fields(source.CommandLine())
+	if len(command) == 0 || command[0] != HelpCommentPrefix {
+		return false
+	}
+	pluginNames := command[1:]
+	return len(pluginNames) == 0 || utils.Contains(pluginNames, c.PluginName) || utils.Contains(pluginNames, "all")
+}
+
+// Description provides the usage and the description of the /help command
+func (c *HelpCmd) Description() CmdDescription {
+	return CmdDescription{
+		Usage:       fmt.Sprintf("%s [%s|all]", HelpCommentPrefix, c.PluginName),
+		Description: "Lists all commands available for the plugin together with the roles that are allowed to use them",
+	}
+}
+
+// WhoCanTrigger allows anyone to trigger the /help command
+func (c *HelpCmd) WhoCanTrigger() PermissionCheck {
+	return Anybody
+}
+
+func (c *HelpCmd) constructMessage(user string) string {
+	var msg bytes.Buffer
+
+	// err is always nil
+	msg.WriteString(fmt.Sprintf(message.PluginTitleTemplate+"\n\n", c.PluginName)) // nolint: errcheck, gosec
+
+	// err is always nil
+	msg.WriteString(fmt.Sprintf( // nolint: errcheck, gosec
+		"Hey @%s! These are the commands you can use to interact with the `%s` plugin:\n\n", user, c.PluginName))
+
+	// err is always nil
+	msg.WriteString("| Command | Description | Who can use it |\n|---|---|---|\n") // nolint: errcheck, gosec
+
+	commands := append(append(make([]CommentCmd, 0, len(c.Commands)+1), c.Commands...), c)
+	for _, cmd := range commands {
+		description := cmd.Description()
+
+		// the status is not evaluated so the error is always nil
+		status, _ := cmd.WhoCanTrigger()(false) // nolint: errcheck
+
+		// err is always nil
+		msg.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", // nolint: errcheck, gosec
+			escapePipes(description.Usage), escapePipes(description.Description), status.describeRoles()))
+	}
+	return msg.String()
+}
+
+// escapePipes escapes the pipes in the given text so it doesn't break the cell of a markdown table
+func escapePipes(text string) string {
+	return strings.Replace(text, "|", "\\|", -1)
+}
@@ -0,0 +1,92 @@
+package command_test
+
+import (
+	is "github.com/arquillian/ike-prow-plugins/pkg/command"
+	. "github.com/arquillian/ike-prow-plugins/pkg/internal/test"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+var _ = Describe("Help command features", func() {
+
+	client := NewDefaultGitHubClient()
+	log := log.NewTestLogger()
+
+	BeforeEach(func() {
+		gock.OffAll()
+	})
+
+	AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+	Context("Listing of registered commands", func() {
+
+		It("should reply with a table containing registered commands and roles that can use them", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				Expecting(Comment(To(
+					HaveBodyThatContains("### Ike Plugins (my-plugin)"),
+					HaveBodyThatContains("Hey @sender! These are the commands you can use to interact with the `my-plugin` plugin"),
+					HaveBodyThatContains("| `/run my-plugin\\|all` | Triggers the plugin on demand"),
+					HaveBodyThatContains("| admin or requested reviewer or pull request approver or pull request creator |"),
+					HaveBodyThatContains("| `/help [my-plugin\\|all]` | Lists all commands available for the plugin"),
+					HaveBodyThatContains("| anyone |")))).
+				Create()
+
+			commandHandler := is.CommentCmdHandler{Client: client, PluginName: "my-plugin"}
+			commandHandler.Register(&is.RunCmd{
+				PluginName:            "my-plugin",
+				UserPermissionService: mock.PermissionForUser("sender").ThatIs(),
+			})
+
+			// when
+			err := commandHandler.Handle(log, is.NewIssueCommentSource(mock.CreateCommentEvent(SentBy("sender"), "/help", "created")))
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should reply to help command containing the plugin name", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				Expecting(Comment(To(HaveBodyThatContains("### Ike Plugins (my-plugin)")))).
+				Create()
+			commandHandler := is.CommentCmdHandler{Client: client, PluginName: "my-plugin"}
+
+			// when
+			err := commandHandler.Handle(log, is.NewIssueCommentSource(mock.CreateCommentEvent(SentBy("sender"), "/help other-plugin my-plugin", "created")))
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should not reply to help command containing only a different plugin name", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				Expecting(NoComment()).
+				Create()
+			commandHandler := is.CommentCmdHandler{Client: client, PluginName: "my-plugin"}
+
+			// when
+			err := commandHandler.Handle(log, is.NewIssueCommentSource(mock.CreateCommentEvent(SentBy("sender"), "/help other-plugin", "created")))
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should not reply to help command when the comment is deleted", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				Expecting(NoComment()).
+				Create()
+			commandHandler := is.CommentCmdHandler{Client: client, PluginName: "my-plugin"}
+
+			// when
+			err := commandHandler.Handle(log, is.NewIssueCommentSource(mock.CreateCommentEvent(SentBy("sender"), "/help", "deleted")))
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,130 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	permissionLevelRolePrefix = "permission:"
+	teamRolePrefix            = "team:"
+)
+
+// PermissionExpression defines which roles are allowed to trigger a command. It's unmarshaled from a plugin
+// configuration file, e.g.:
+//
+//	any_of: [admin, approver, team:org/leads]
+//	none_of: [pr_creator]
+//
+// The supported roles are admin, reviewer, approver, pr_creator, code_owner, code_owner_of_all_files, anyone,
+// permission:<level> (e.g. permission:write) and team:<org>/<team-slug>
+type PermissionExpression struct {
+	AnyOf  []string `yaml:"any_of,omitempty"`
+	AllOf  []string `yaml:"all_of,omitempty"`
+	NoneOf []string `yaml:"none_of,omitempty"`
+}
+
+// CommandPermissions maps a command name (without the leading slash, e.g. "run" or "ok-without-tests") to
+// the PermissionExpression restricting it
+type CommandPermissions map[string]PermissionExpression
+
+// PermissionsLoader loads CommandPermissions configured for the repository the command is triggered in
+type PermissionsLoader func() CommandPermissions
+
+// IsEmpty says if there is no role defined in the expression
+func (e PermissionExpression) IsEmpty() bool {
+	return len(e.AnyOf) == 0 && len(e.AllOf) == 0 && len(e.NoneOf) == 0
+}
+
+// Compile creates a PermissionCheck for the given user from the expression using AnyOf, AllOf and Not combinators
+func (e PermissionExpression) Compile(user *PermissionService) (PermissionCheck, error) {
+	var checks []PermissionCheck
+
+	if len(e.AnyOf) > 0 {
+		anyOfChecks, err := compileRoles(user, e.AnyOf)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, AnyOf(anyOfChecks...))
+	}
+
+	allOfChecks, err := compileRoles(user, e.AllOf)
+	if err != nil {
+		return nil, err
+	}
+	checks = append(checks, allOfChecks...)
+
+	noneOfChecks, err := compileRoles(user, e.NoneOf)
+	if err != nil {
+		return nil, err
+	}
+	for _, check := range noneOfChecks {
+		checks = append(checks, Not(check))
+	}
+
+	return AllOf(checks...), nil
+}
+
+// ConfiguredOrDefault creates a PermissionCheck for the given command that is compiled from the PermissionExpression
+// loaded by the given PermissionsLoader. If there is no expression configured for the command then the default check is used.
+// The configuration is loaded lazily - when the check is called
+func ConfiguredOrDefault(command string, user *PermissionService, loadPermissions PermissionsLoader, defaultCheck PermissionCheck) PermissionCheck {
+	return func(evaluate bool) (*PermissionStatus, error) {
+		if loadPermissions == nil {
+			return defaultCheck(evaluate)
+		}
+		expression, found := loadPermissions()[strings.TrimPrefix(command, "/")]
+		if !found || expression.IsEmpty() {
+			return defaultCheck(evaluate)
+		}
+		check, err := expression.Compile(user)
+		if err != nil {
+			return user.newPermissionStatus().reject(), err
+		}
+		return check(evaluate)
+	}
+}
+
+func compileRoles(user *PermissionService, roles []string) ([]PermissionCheck, error) {
+	checks := make([]PermissionCheck, 0, len(roles))
+	for _, role := range roles {
+		check, err := compileRole(user, strings.TrimSpace(role))
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+func compileRole(user *PermissionService, role string) (PermissionCheck, error) {
+	switch {
+	case role == "admin":
+		return user.Admin, nil
+	case role == "reviewer":
+		return user.PRReviewer, nil
+	case role == "approver":
+		return user.PRApprover, nil
+	case role == "pr_creator":
+		return user.PRCreator, nil
+	case role == "code_owner":
+		return user.PRCodeOwner, nil
+	case role == "code_owner_of_all_files":
+		return user.PRCodeOwnerOfAllFiles, nil
+	case role == Anyone:
+		return Anybody, nil
+	case strings.HasPrefix(role, permissionLevelRolePrefix):
+		level := strings.TrimPrefix(role, permissionLevelRolePrefix)
+		if permissionRank(level) < 0 {
+			return nil, fmt.Errorf("unknown permission level %q in role %q, expected one of %v", level, role, PermissionLevels)
+		}
+		return user.MinPermission(level), nil
+	case strings.HasPrefix(role, teamRolePrefix):
+		team := strings.TrimPrefix(strings.TrimPrefix(role, teamRolePrefix), "@")
+		if _, _, err := parseTeam(team); err != nil {
+			return nil, err
+		}
+		return user.OrgTeamMember(team), nil
+	}
+	return nil, fmt.Errorf("unknown role %q", role)
+}
@@ -0,0 +1,124 @@
+package command_test
+
+import (
+	is "github.com/arquillian/ike-prow-plugins/pkg/command"
+	. "github.com/arquillian/ike-prow-plugins/pkg/internal/test"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gock "gopkg.in/h2non/gock.v1"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("Configurable command permissions", func() {
+
+	BeforeEach(func() {
+		gock.Off()
+	})
+
+	AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+	permissionsFrom := func(config string) is.PermissionsLoader {
+		return func() is.CommandPermissions {
+			permissions := is.CommandPermissions{}
+			Expect(yaml.Unmarshal([]byte(config), &permissions)).To(Succeed())
+			return permissions
+		}
+	}
+
+	It("should use the default check when there are no permissions configured for the command", func() {
+		// given
+		mock := MockPr().LoadedFromDefaultStruct().
+			WithUsers(Admin("user")).
+			Create()
+		user := mock.PermissionForUser("user").ThatIs()
+
+		// when
+		status, err := is.ConfiguredOrDefault("/run", user, permissionsFrom("ok-without-tests:\n  any_of: [reviewer]\n"), user.Admin)(true)
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		ExpectPermissionStatus(status).To(
+			HaveApprovedUser("user"),
+			HaveApprovedRoles(is.Admin))
+	})
+
+	It("should use the default check when there is no permissions loader", func() {
+		// given
+		mock := MockPr().LoadedFromDefaultStruct().
+			WithUsers(ExternalUser("user")).
+			Create()
+		user := mock.PermissionForUser("user").ThatIs()
+
+		// when
+		status, err := is.ConfiguredOrDefault("/run", user, nil, user.Admin)(true)
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		ExpectPermissionStatus(status).To(HaveRejectedUser("user"))
+	})
+
+	It("should approve the user matching any_of roles and not matching none_of roles", func() {
+		// given
+		mock := MockPr().LoadedFromDefaultStruct().
+			WithUsers(Collaborator("user", "write")).
+			Create()
+		user := mock.PermissionForUser("user").ThatIs()
+		config := "run:\n  any_of: [admin, \"permission:write\"]\n  none_of: [pr_creator]\n"
+
+		// when
+		status, err := is.ConfiguredOrDefault("/run", user, permissionsFrom(config), user.Admin)(true)
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		ExpectPermissionStatus(status).To(
+			HaveApprovedUser("user"),
+			HaveApprovedRoles(is.Admin, "user with write permission or higher"),
+			HaveRejectedRoles(is.PullRequestCreator))
+	})
+
+	It("should reject the user not having all of the configured roles", func() {
+		// given
+		mock := MockPr().LoadedFromDefaultStruct().
+			WithUsers(Collaborator("user", "write")).
+			WithoutTeamMembers("org", "leads", "user").
+			Create()
+		user := mock.PermissionForUser("user").ThatIs()
+		config := "run:\n  all_of: [\"permission:write\", \"team:org/leads\"]\n"
+
+		// when
+		status, err := is.ConfiguredOrDefault("/run", user, permissionsFrom(config), is.Anybody)(true)
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		ExpectPermissionStatus(status).To(
+			HaveRejectedUser("user"),
+			HaveApprovedRoles("user with write permission or higher", "member of @org/leads team"))
+	})
+
+	It("should fail when an unknown role is configured", func() {
+		// given
+		mock := MockPr().LoadedFromDefaultStruct().Create()
+		user := mock.PermissionForUser("user").ThatIs()
+
+		// when
+		status, err := is.ConfiguredOrDefault("/run", user, permissionsFrom("run:\n  any_of: [maintainer]\n"), is.Anybody)(true)
+
+		// then
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).To(ContainSubstring(`unknown role "maintainer"`))
+		ExpectPermissionStatus(status).To(HaveRejectedUser("user"))
+	})
+
+	It("should fail when an unknown permission level is configured", func() {
+		// given
+		mock := MockPr().LoadedFromDefaultStruct().Create()
+		user := mock.PermissionForUser("user").ThatIs()
+
+		// when
+		_, err := is.ConfiguredOrDefault("run", user, permissionsFrom("run:\n  any_of: [\"permission:owner\"]\n"), is.Anybody)(true)
+
+		// then
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).To(ContainSubstring(`unknown permission level "owner"`))
+	})
+})
@@ -1,15 +1,23 @@
 package command
 
 import (
+	"fmt"
+	"strings"
+
 	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
 	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
+	"github.com/arquillian/ike-prow-plugins/pkg/scm"
 )
 
+// PermissionLevels contains repository permission levels ordered from the lowest to the highest one
+var PermissionLevels = []string{"read", "triage", "write", "maintain", "admin"}
+
 // PermissionService keeps user name and PR loader and provides information about the user's permissions
 type PermissionService struct {
-	client   ghclient.Client
-	user     string
-	prLoader *ghservice.PullRequestLazyLoader
+	client          ghclient.Client
+	user            string
+	prLoader        *ghservice.PullRequestLazyLoader
+	permissionLevel string
 }
 
 // NewPermissionService creates a new instance of PermissionService with the given client, user and pr loader
@@ -31,17 +39,174 @@ func (s *PermissionService) Admin(evaluate bool) (*PermissionStatus, error) {
 	if !evaluate {
 		return status, nil
 	}
-	permissionLevel, err := s.client.GetPermissionLevel(s.prLoader.RepoOwner, s.prLoader.RepoName, s.user)
+	permissionLevel, err := s.loadPermissionLevel()
 	if err != nil {
 		return status.reject(), err
 	}
 
-	if *permissionLevel.Permission == Admin {
+	if permissionLevel == Admin {
 		return status.allow(), nil
 	}
 	return status.reject(), nil
 }
 
+// MinPermission creates a permission check verifying that the user has at least the given repository permission level
+// (see PermissionLevels for the possible values)
+func (s *PermissionService) MinPermission(level string) PermissionCheck {
+	return func(evaluate bool) (*PermissionStatus, error) {
+		status := s.newPermissionStatus(fmt.Sprintf(MinPermissionLevel, level))
+		if !evaluate {
+			return status, nil
+		}
+		minRank := permissionRank(level)
+		if minRank < 0 {
+			return status.reject(), fmt.Errorf("unknown permission level %q, expected one of %v", level, PermissionLevels)
+		}
+		permissionLevel, err := s.loadPermissionLevel()
+		if err != nil {
+			return status.reject(), err
+		}
+		if permissionRank(permissionLevel) >= minRank {
+			return status.allow(), nil
+		}
+		return status.reject(), nil
+	}
+}
+
+// OrgTeamMember creates a permission check verifying that the user is an active member of the given organization team.
+// The team is expected in the format "org/team-slug" (optionally prefixed with "@")
+func (s *PermissionService) OrgTeamMember(team string) PermissionCheck {
+	team = strings.TrimPrefix(strings.TrimSpace(team), "@")
+	return func(evaluate bool) (*PermissionStatus, error) {
+		status := s.newPermissionStatus(fmt.Sprintf(TeamMember, team))
+		if !evaluate {
+			return status, nil
+		}
+		org, teamSlug, err := parseTeam(team)
+		if err != nil {
+			return status.reject(), err
+		}
+		isMember, err := s.client.IsTeamMember(org, teamSlug, s.user)
+		if err != nil {
+			return status.reject(), err
+		}
+		if isMember {
+			return status.allow(), nil
+		}
+		return status.reject(), nil
+	}
+}
+
+// PRCodeOwner checks if the user (or any team the user is member of) is listed in the CODEOWNERS file of the base
+// revision of the pull request as an owner of any of the changed files
+func (s *PermissionService) PRCodeOwner(evaluate bool) (*PermissionStatus, error) {
+	return s.checkCodeOwnership(evaluate, CodeOwner, false)
+}
+
+// PRCodeOwnerOfAllFiles checks if the user (or any team the user is member of) is listed in the CODEOWNERS file of the
+// base revision of the pull request as an owner of all the changed files. Files without any owner are not owned by the user
+func (s *PermissionService) PRCodeOwnerOfAllFiles(evaluate bool) (*PermissionStatus, error) {
+	return s.checkCodeOwnership(evaluate, CodeOwnerOfAllFiles, true)
+}
+
+func (s *PermissionService) checkCodeOwnership(evaluate bool, role string, ofAllFiles bool) (*PermissionStatus, error) {
+	status := s.newPermissionStatus(role)
+	if !evaluate {
+		return status, nil
+	}
+	pr, err := s.prLoader.Load()
+	if err != nil {
+		return status.reject(), err
+	}
+	codeOwners, err := loadCodeOwners(scm.RepositoryChange{Owner: s.prLoader.RepoOwner, RepoName: s.prLoader.RepoName, Hash: pr.GetBase().GetSHA()})
+	if err != nil {
+		return status.reject(), err
+	}
+	if codeOwners == nil {
+		return status.reject(), nil
+	}
+	changedFiles, err := s.client.ListPullRequestFiles(s.prLoader.RepoOwner, s.prLoader.RepoName, s.prLoader.Number)
+	if err != nil {
+		return status.reject(), err
+	}
+
+	teamMemberships := make(map[string]bool)
+	for _, file := range changedFiles {
+		isOwner, err := s.isOwner(codeOwners.Owners(file.Name), teamMemberships)
+		if err != nil {
+			return status.reject(), err
+		}
+		if isOwner && !ofAllFiles {
+			return status.allow(), nil
+		}
+		if !isOwner && ofAllFiles {
+			return status.reject(), nil
+		}
+	}
+	if ofAllFiles && len(changedFiles) > 0 {
+		return status.allow(), nil
+	}
+	return status.reject(), nil
+}
+
+func (s *PermissionService) isOwner(owners []string, teamMemberships map[string]bool) (bool, error) {
+	for _, owner := range owners {
+		if !strings.HasPrefix(owner, "@") {
+			continue
+		}
+		owner = strings.TrimPrefix(owner, "@")
+		if !strings.Contains(owner, "/") {
+			if strings.EqualFold(owner, s.user) {
+				return true, nil
+			}
+			continue
+		}
+		isMember, checked := teamMemberships[owner]
+		if !checked {
+			org, teamSlug, err := parseTeam(owner)
+			if err != nil {
+				return false, err
+			}
+			if isMember, err = s.client.IsTeamMember(org, teamSlug, s.user); err != nil {
+				return false, err
+			}
+			teamMemberships[owner] = isMember
+		}
+		if isMember {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *PermissionService) loadPermissionLevel() (string, error) {
+	if s.permissionLevel == "" {
+		permissionLevel, err := s.client.GetPermissionLevel(s.prLoader.RepoOwner, s.prLoader.RepoName, s.user)
+		if err != nil {
+			return "", err
+		}
+		s.permissionLevel = permissionLevel.GetPermission()
+	}
+	return s.permissionLevel, nil
+}
+
+func permissionRank(level string) int {
+	for rank, permissionLevel := range PermissionLevels {
+		if strings.EqualFold(permissionLevel, level) {
+			return rank
+		}
+	}
+	return -1
+}
+
+func parseTeam(team string) (org, teamSlug string, err error) {
+	parts := strings.Split(team, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid team %q, expected format is org/team-slug", team)
+	}
+	return parts[0], parts[1], nil
+}
+
 // PRReviewer checks if the user is pull request reviewer
 func (s *PermissionService) PRReviewer(evaluate bool) (*PermissionStatus, error) {
 	status := s.newPermissionStatus(RequestedReviewer)
@@ -1,6 +1,8 @@
 package command_test
 
 import (
+	"fmt"
+
 	is "github.com/arquillian/ike-prow-plugins/pkg/command"
 	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
 	. "github.com/arquillian/ike-prow-plugins/pkg/internal/test"
@@ -155,6 +157,217 @@ var _ = Describe("Permission service with permission checks features", func() {
 				HaveApprovedRoles(is.PullRequestApprover),
 				HaveNoRejectedRoles())
 		})
+
+		It("should approve the user with maintain permission when write permission level is required", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				WithUsers(Collaborator("user", "maintain")).
+				Create()
+
+			// when
+			status, err := mock.PermissionForUser("user").ThatIs().MinPermission("write")(true)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			ExpectPermissionStatus(status).To(
+				HaveApprovedUser("user"),
+				HaveApprovedRoles(fmt.Sprintf(is.MinPermissionLevel, "write")),
+				HaveNoRejectedRoles())
+		})
+
+		It("should not approve the user with triage permission when write permission level is required", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				WithUsers(Collaborator("user", "triage")).
+				Create()
+
+			// when
+			status, err := mock.PermissionForUser("user").ThatIs().MinPermission("write")(true)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			ExpectPermissionStatus(status).To(
+				HaveRejectedUser("user"),
+				HaveApprovedRoles(fmt.Sprintf(is.MinPermissionLevel, "write")),
+				HaveNoRejectedRoles())
+		})
+
+		It("should use the role name instead of the permission when it is available", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().Create()
+			gock.New("https://api.github.com").
+				Get("/repos/bartoszmajsak/wfswarm-booster-pipeline-test/collaborators/user/permission").
+				Reply(200).
+				BodyString(`{"permission":"write","role_name":"maintain","user":{"login":"user"}}`)
+
+			// when
+			status, err := mock.PermissionForUser("user").ThatIs().MinPermission("maintain")(true)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			ExpectPermissionStatus(status).To(HaveApprovedUser("user"))
+		})
+
+		It("should return an error when the required permission level is unknown", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().Create()
+
+			// when
+			status, err := mock.PermissionForUser("user").ThatIs().MinPermission("superuser")(true)
+
+			// then
+			Ω(err).Should(HaveOccurred())
+			ExpectPermissionStatus(status).To(HaveRejectedUser("user"))
+		})
+
+		It("should approve the user that is a member of the team", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				WithTeamMembers("org", "qa", "user").
+				Create()
+
+			// when
+			status, err := mock.PermissionForUser("user").ThatIs().OrgTeamMember("@org/qa")(true)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			ExpectPermissionStatus(status).To(
+				HaveApprovedUser("user"),
+				HaveApprovedRoles(fmt.Sprintf(is.TeamMember, "org/qa")),
+				HaveNoRejectedRoles())
+		})
+
+		It("should not approve the user that is not a member of the team", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().
+				WithoutTeamMembers("org", "qa", "user").
+				Create()
+
+			// when
+			status, err := mock.PermissionForUser("user").ThatIs().OrgTeamMember("org/qa")(true)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			ExpectPermissionStatus(status).To(
+				HaveRejectedUser("user"),
+				HaveApprovedRoles(fmt.Sprintf(is.TeamMember, "org/qa")),
+				HaveNoRejectedRoles())
+		})
+
+		It("should return an error when the team is not in org/team-slug format", func() {
+			// given
+			mock := MockPr().LoadedFromDefaultStruct().Create()
+
+			// when
+			status, err := mock.PermissionForUser("user").ThatIs().OrgTeamMember("qa")(true)
+
+			// then
+			Ω(err).Should(HaveOccurred())
+			ExpectPermissionStatus(status).To(HaveRejectedUser("user"))
+		})
+
+		Context("Code owners checks", func() {
+
+			codeOwners := "*.md @doc-writer\n/pkg/ @pkg-owner @org/pkg-team\n"
+			changedFiles := `[{"filename":"README.md","status":"modified","additions":1,"deletions":0},` +
+				`{"filename":"pkg/main.go","status":"modified","additions":1,"deletions":0}]`
+
+			It("should approve the user that owns any of the changed files", func() {
+				// given
+				mock := MockPr().LoadedFromDefaultStruct().
+					WithCodeOwners(codeOwners).
+					WithFiles(changedFiles).
+					Create()
+
+				// when
+				status, err := mock.PermissionForUser("doc-writer").ThatIs().PRCodeOwner(true)
+
+				// then
+				Ω(err).ShouldNot(HaveOccurred())
+				ExpectPermissionStatus(status).To(
+					HaveApprovedUser("doc-writer"),
+					HaveApprovedRoles(is.CodeOwner),
+					HaveNoRejectedRoles())
+			})
+
+			It("should approve the user that is a member of a team owning any of the changed files", func() {
+				// given
+				mock := MockPr().LoadedFromDefaultStruct().
+					WithCodeOwners(codeOwners).
+					WithFiles(changedFiles).
+					WithTeamMembers("org", "pkg-team", "user").
+					Create()
+
+				// when
+				status, err := mock.PermissionForUser("user").ThatIs().PRCodeOwner(true)
+
+				// then
+				Ω(err).ShouldNot(HaveOccurred())
+				ExpectPermissionStatus(status).To(HaveApprovedUser("user"))
+			})
+
+			It("should not approve the user that owns only some of the changed files when all of them are required", func() {
+				// given
+				mock := MockPr().LoadedFromDefaultStruct().
+					WithCodeOwners(codeOwners).
+					WithFiles(changedFiles).
+					WithoutTeamMembers("org", "pkg-team", "doc-writer").
+					Create()
+
+				// when
+				status, err := mock.PermissionForUser("doc-writer").ThatIs().PRCodeOwnerOfAllFiles(true)
+
+				// then
+				Ω(err).ShouldNot(HaveOccurred())
+				ExpectPermissionStatus(status).To(
+					HaveRejectedUser("doc-writer"),
+					HaveApprovedRoles(is.CodeOwnerOfAllFiles),
+					HaveNoRejectedRoles())
+			})
+
+			It("should approve the user that owns all the changed files", func() {
+				// given
+				mock := MockPr().LoadedFromDefaultStruct().
+					WithCodeOwners("* @owner\n").
+					WithFiles(changedFiles).
+					Create()
+
+				// when
+				status, err := mock.PermissionForUser("owner").ThatIs().PRCodeOwnerOfAllFiles(true)
+
+				// then
+				Ω(err).ShouldNot(HaveOccurred())
+				ExpectPermissionStatus(status).To(HaveApprovedUser("owner"))
+			})
+
+			It("should not approve the user when there is no CODEOWNERS file", func() {
+				// given
+				mock := MockPr().LoadedFromDefaultStruct().Create()
+				NonExistingRawGitHubFiles(is.CodeOwnersLocations...)
+
+				// when
+				status, err := mock.PermissionForUser("owner").ThatIs().PRCodeOwner(true)
+
+				// then
+				Ω(err).ShouldNot(HaveOccurred())
+				ExpectPermissionStatus(status).To(HaveRejectedUser("owner"))
+			})
+
+			It("should not approve the user and report an error when CODEOWNERS file can't be loaded", func() {
+				// given
+				mock := MockPr().LoadedFromDefaultStruct().Create()
+				gock.New("https://raw.githubusercontent.com").
+					Path(`/\.github/CODEOWNERS$`).
+					Reply(500)
+
+				// when
+				status, err := mock.PermissionForUser("owner").ThatIs().PRCodeOwner(true)
+
+				// then
+				Ω(err).Should(MatchError(ContainSubstring("failed to load .github/CODEOWNERS file")))
+				ExpectPermissionStatus(status).To(HaveRejectedUser("owner"))
+			})
+		})
 	})
 
 	Context("Permission check functions", func() {
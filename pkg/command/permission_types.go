@@ -15,6 +15,14 @@ var (
 	PullRequestCreator = "pull request creator"
 	// PullRequestApprover is a name of a person who gave an approval to the PR
 	PullRequestApprover = "pull request approver"
+	// CodeOwner is a name of the role given to code owners of any of the files changed in the pull request
+	CodeOwner = "code owner of any of the changed files"
+	// CodeOwnerOfAllFiles is a name of the role given to code owners of all the files changed in the pull request
+	CodeOwnerOfAllFiles = "code owner of all the changed files"
+	// MinPermissionLevel is a template of a name of the role given to users having at least the given repository permission level
+	MinPermissionLevel = "user with %s permission or higher"
+	// TeamMember is a template of a name of the role given to members of the given organization team
+	TeamMember = "member of @%s team"
 	// Unknown represents an unknown user
 	Unknown = "unknown"
 	// Anyone represents any user/role
@@ -58,6 +66,19 @@ func (s *PermissionStatus) constructMessage(operation, command string) string {
 			"You have to be ",
 		s.User, operation, command))
 
+	// err is always nil
+	msg.WriteString(s.describeRoles()) // nolint: errcheck, gosec
+
+	// err is always nil
+	msg.WriteString(" for this command to take an effect. ") // nolint: errcheck, gosec
+	return msg.String()
+}
+
+// describeRoles creates a human readable description of the approved and rejected roles,
+// e.g. "admin or requested reviewer, but not pull request creator"
+func (s *PermissionStatus) describeRoles() string {
+	var msg bytes.Buffer
+
 	if len(s.ApprovedRoles) > 0 {
 		// err is always nil
 		msg.WriteString(strings.Join(s.ApprovedRoles, " or ")) // nolint: errcheck, gosec
@@ -71,8 +92,5 @@ func (s *PermissionStatus) constructMessage(operation, command string) string {
 		// err is always nil
 		msg.WriteString("not " + strings.Join(s.RejectedRoles, " nor ")) // nolint: errcheck, gosec
 	}
-
-	// err is always nil
-	msg.WriteString(" for this command to take an effect. ") // nolint: errcheck, gosec
 	return msg.String()
 }
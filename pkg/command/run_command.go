@@ -1,12 +1,12 @@
 package command
 
 import (
+	"fmt"
 	"strings"
 
 	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
 	"github.com/arquillian/ike-prow-plugins/pkg/log"
 	"github.com/arquillian/ike-prow-plugins/pkg/utils"
-	gogh "github.com/google/go-github/v41/github"
 )
 
 // RunCommentPrefix is used as a command prefix to trigger plugin with it's name
@@ -16,26 +16,45 @@ const RunCommentPrefix = "/run"
 type RunCmd struct {
 	PluginName            string
 	UserPermissionService *PermissionService
+	Permissions           PermissionsLoader
+	Feedback              FeedbackLoader
 	WhenAddedOrEdited     DoFunction
 }
 
-// Perform executes the set DoFunctions for the given IssueCommentEvent (when all conditions are fulfilled)
-func (c *RunCmd) Perform(client ghclient.Client, logger log.Logger, comment *gogh.IssueCommentEvent) error {
-	user := c.UserPermissionService
-	var RunCommand = &CmdExecutor{Command: RunCommentPrefix}
+// Perform executes the set DoFunctions for the given CmdSource (when all conditions are fulfilled)
+func (c *RunCmd) Perform(client ghclient.Client, logger log.Logger, source *CmdSource) error {
+	var RunCommand = &CmdExecutor{Command: RunCommentPrefix, Feedback: c.Feedback}
+	if c.UserPermissionService != nil {
+		RunCommand.PullRequest = c.UserPermissionService.prLoader
+	}
 
 	RunCommand.
 		When(Triggered).
-		By(AnyOf(user.Admin, user.PRReviewer, user.PRApprover, user.PRCreator)).
+		By(c.WhoCanTrigger()).
 		Then(c.WhenAddedOrEdited)
 
-	return RunCommand.Execute(client, logger, comment)
+	return RunCommand.Execute(client, logger, source)
 }
 
-// Matches returns true when the given IssueCommentEvent content prefix is "/run"
-func (c *RunCmd) Matches(comment *gogh.IssueCommentEvent) bool {
-	body := strings.TrimSpace(*comment.Comment.Body)
-	command := strings.Split(body, " ")
+// Matches returns true when the given CmdSource content prefix is "/run"
+func (c *RunCmd) Matches(source *CmdSource) bool {
+	command := strings.Split(source.CommandLine(), " ")
 	pluginNames := command[1:]
 	return command[0] == RunCommentPrefix && (utils.Contains(pluginNames, c.PluginName) || utils.Contains(pluginNames, "all"))
 }
+
+// Description provides the usage and the description of the /run command
+func (c *RunCmd) Description() CmdDescription {
+	return CmdDescription{
+		Usage:       fmt.Sprintf("%s %s|all", RunCommentPrefix, c.PluginName),
+		Description: "Triggers the plugin on demand (multiple plugin names separated by space can be used)",
+	}
+}
+
+// WhoCanTrigger returns the permission check configured for the /run command. If there is none, then it allows admin,
+// requested reviewer, approver or creator of the pull request to trigger the command
+func (c *RunCmd) WhoCanTrigger() PermissionCheck {
+	user := c.UserPermissionService
+	return ConfiguredOrDefault(RunCommentPrefix, user, c.Permissions,
+		AnyOf(user.Admin, user.PRReviewer, user.PRApprover, user.PRCreator))
+}
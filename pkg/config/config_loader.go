@@ -21,6 +21,12 @@ type PluginConfiguration struct {
 // Load loads configuration of the plugin based on strategies defined by SourcesProvider
 // It ignores errors returned by providers and only propagates the one occurred while unmarshalling
 func Load(target interface{}, loader SourcesProvider) error {
+	return LoadAll(loader, target)
+}
+
+// LoadAll loads configuration of the plugin the same way as Load does, but unmarshals it to all the given targets,
+// so the configuration file is retrieved only once
+func LoadAll(loader SourcesProvider, targets ...interface{}) error {
 	var source []byte
 	for _, load := range loader.Sources() {
 		loaded, err := load()
@@ -29,5 +35,10 @@ func Load(target interface{}, loader SourcesProvider) error {
 			break
 		}
 	}
-	return yaml.Unmarshal(source, target)
+	for _, target := range targets {
+		if err := yaml.Unmarshal(source, target); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -74,6 +74,31 @@ var _ = Describe("Config loader features", func() {
 			Expect(sampleConfig.Name).To(Equal("awesome-o"))
 		})
 
+		It("should load configuration into all targets retrieving it only once", func() {
+			// given
+			retrieved := 0
+			testConfigProviders := testConfigProvider(func() []config.Source {
+				return []config.Source{func() ([]byte, error) {
+					retrieved++
+					return nameAndSkip()
+				}}
+			})
+
+			sampleConfig := sampleConfiguration{}
+			skipOnly := struct {
+				Skip []string `yaml:"skip_validation_for,omitempty"`
+			}{}
+
+			// when
+			err := config.LoadAll(testConfigProviders, &sampleConfig, &skipOnly)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(retrieved).To(Equal(1))
+			Expect(sampleConfig.Name).To(Equal("name-and-skip"))
+			Expect(skipOnly.Skip).To(ConsistOf("anything"))
+		})
+
 		It("should load configuration when failing and successful lookup provided, skipping first failing", func() {
 			// given
 			testConfigProviders := testConfigProvider(func() []config.Source {
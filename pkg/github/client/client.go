@@ -2,6 +2,7 @@ package ghclient
 
 import (
 	"context"
+	"net/http"
 
 	"fmt"
 
@@ -11,6 +12,14 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// PullRequestFilesLimit is the maximum number of files GitHub lists for a pull request. The list of files of a pull
+// request changing more of them is truncated
+const PullRequestFilesLimit = 3000
+
+// PullRequestCommitsLimit is the maximum number of commits GitHub lists for a pull request. The list of commits of a pull
+// request containing more of them is truncated
+const PullRequestCommitsLimit = 250
+
 type client struct {
 	logger    log.Logger
 	gh        *gogh.Client
@@ -20,12 +29,22 @@ type client struct {
 // Client manages communication with the GitHub API.
 type Client interface {
 	GetPermissionLevel(owner, repo, user string) (*gogh.RepositoryPermissionLevel, error)
+	IsTeamMember(org, teamSlug, user string) (bool, error)
 	GetPullRequest(owner, repo string, prNumber int) (*gogh.PullRequest, error)
 	ListPullRequestFiles(owner, repo string, prNumber int) ([]scm.ChangedFile, error)
+	ListChangedFiles(pr *gogh.PullRequest) ([]scm.ChangedFile, error)
 	GetPullRequestReviews(owner, repo string, prNumber int) ([]*gogh.PullRequestReview, error)
+	ListPullRequestCommits(owner, repo string, prNumber int) ([]*gogh.RepositoryCommit, error)
+	CompareCommits(owner, repo, base, head string) ([]scm.ChangedFile, error)
+	ListStatuses(owner, repo, ref string) ([]*gogh.RepoStatus, error)
+	ListLanguages(owner, repo string) (map[string]int, error)
+	GetIssue(owner, repo string, number int) (*gogh.Issue, error)
 	ListIssueComments(issue scm.RepositoryIssue) ([]*gogh.IssueComment, error)
+	ListIssueEvents(issue scm.RepositoryIssue) ([]*gogh.IssueEvent, error)
 	CreateIssueComment(issue scm.RepositoryIssue, commentMsg *string) error
 	EditIssueComment(issue scm.RepositoryIssue, commentID int64, commentMsg *string) error
+	CreateIssueCommentReaction(issue scm.RepositoryIssue, commentID int64, reaction string) error
+	CreatePullRequestCommentReaction(issue scm.RepositoryIssue, commentID int64, reaction string) error
 	CreateStatus(change scm.RepositoryChange, repoStatus *gogh.RepoStatus) error
 	AddPullRequestLabel(change scm.RepositoryChange, prNumber int, label []string) error
 	RemovePullRequestLabel(change scm.RepositoryChange, prNumber int, label string) error
@@ -75,20 +94,54 @@ func (c *client) do(function doFunction) error {
 	return e
 }
 
+// repositoryPermissionLevel extends gogh.RepositoryPermissionLevel by the role name which (in contrast to the permission)
+// distinguishes also "maintain" and "triage" levels
+type repositoryPermissionLevel struct {
+	gogh.RepositoryPermissionLevel
+	RoleName *string `json:"role_name,omitempty"`
+}
+
 // GetPermissionLevel retrieves the specific permission level a collaborator has for a given repository.
+// When GitHub provides the role name of the collaborator then it is used as the permission so the value is one of
+// "admin", "maintain", "write", "triage", "read" or "none".
 func (c *client) GetPermissionLevel(owner, repo, user string) (*gogh.RepositoryPermissionLevel, error) {
 	var permissionLevel *gogh.RepositoryPermissionLevel
 
 	err := c.do(func(aroundContext aroundContext) (func(), *gogh.Response, error) {
-		level, response, e := c.gh.Repositories.GetPermissionLevel(context.Background(), owner, repo, user)
+		request, e := c.gh.NewRequest(http.MethodGet, fmt.Sprintf("repos/%v/%v/collaborators/%v/permission", owner, repo, user), nil)
+		if e != nil {
+			return func() {}, nil, e
+		}
+		level := &repositoryPermissionLevel{}
+		response, e := c.gh.Do(context.Background(), request, level)
 		return func() {
-			permissionLevel = level
+			if level.RoleName != nil && *level.RoleName != "" {
+				level.Permission = level.RoleName
+			}
+			permissionLevel = &level.RepositoryPermissionLevel
 		}, response, c.checkHTTPCode(response, e)
 	})
 
 	return permissionLevel, err
 }
 
+// IsTeamMember checks if the given user is an active member of the organization team with the given slug.
+func (c *client) IsTeamMember(org, teamSlug, user string) (bool, error) {
+	isMember := false
+
+	err := c.do(func(aroundContext aroundContext) (func(), *gogh.Response, error) {
+		membership, response, e := c.gh.Teams.GetTeamMembershipBySlug(context.Background(), org, teamSlug, user)
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return func() {}, response, nil
+		}
+		return func() {
+			isMember = membership.GetState() == "active"
+		}, response, c.checkHTTPCode(response, e)
+	})
+
+	return isMember, err
+}
+
 // GetPullRequest retrieves information about a single pull request.
 func (c *client) GetPullRequest(owner, repo string, prNumber int) (*gogh.PullRequest, error) {
 	var pullRequest *gogh.PullRequest
@@ -133,6 +186,113 @@ func (c *client) ListPullRequestFiles(owner, repo string, prNumber int) ([]scm.C
 	return changedFiles, err
 }
 
+// TruncatedFilesError is returned by ListChangedFiles together with the listed files when the pull request changes
+// more files than GitHub is able to list. Total is zero when the number of the changed files is not known
+type TruncatedFilesError struct {
+	Listed, Total int
+}
+
+func (e *TruncatedFilesError) Error() string {
+	if e.Total == 0 {
+		return fmt.Sprintf("the list of changed files has been truncated to %d files", e.Listed)
+	}
+	return fmt.Sprintf("the list of changed files has been truncated to %d of %d files", e.Listed, e.Total)
+}
+
+// ListChangedFiles lists the changed files in the given pull request. When the pull request changes more files than
+// GitHub lists (see PullRequestFilesLimit), then the listed ones are returned together with TruncatedFilesError.
+// The compare API can't be used as a fallback as it lists even fewer files (300) of the comparison.
+func (c *client) ListChangedFiles(pr *gogh.PullRequest) ([]scm.ChangedFile, error) {
+	repo := pr.GetBase().GetRepo()
+	changedFiles, err := c.ListPullRequestFiles(repo.GetOwner().GetLogin(), repo.GetName(), pr.GetNumber())
+	if err != nil {
+		return changedFiles, err
+	}
+	if total := pr.GetChangedFiles(); len(changedFiles) >= PullRequestFilesLimit && (total == 0 || total > len(changedFiles)) {
+		return changedFiles, &TruncatedFilesError{Listed: len(changedFiles), Total: total}
+	}
+	return changedFiles, nil
+}
+
+// ListPullRequestCommits lists the commits of a pull request (at most PullRequestCommitsLimit of them).
+func (c *client) ListPullRequestCommits(owner, repo string, prNumber int) ([]*gogh.RepositoryCommit, error) {
+	prCommits := make([]*gogh.RepositoryCommit, 0)
+
+	err := c.do(func(aroundCtx aroundContext) (func(), *gogh.Response, error) {
+		commits, response, e := c.gh.PullRequests.ListCommits(context.Background(), owner, repo, prNumber, listOpts(aroundCtx))
+		return func() {
+			prCommits = append(prCommits, commits...)
+		}, response, c.checkHTTPCode(response, e)
+	})
+
+	return prCommits, err
+}
+
+// CompareCommits lists the files changed between the base and head commits (base...head).
+func (c *client) CompareCommits(owner, repo, base, head string) ([]scm.ChangedFile, error) {
+	changedFiles := make([]scm.ChangedFile, 0)
+
+	err := c.do(func(aroundCtx aroundContext) (func(), *gogh.Response, error) {
+		comparison, response, e := c.gh.Repositories.CompareCommits(context.Background(), owner, repo, base, head, listOpts(aroundCtx))
+		return func() {
+			// only commits are paginated, the list of files is the same for all pages
+			if comparison == nil || aroundCtx.pageNumber > 1 {
+				return
+			}
+			for _, file := range comparison.Files {
+				changedFiles = append(changedFiles, *scm.NewChangedFile(file))
+			}
+		}, response, c.checkHTTPCode(response, e)
+	})
+
+	return changedFiles, err
+}
+
+// ListStatuses lists the statuses of the given ref (the newest first).
+func (c *client) ListStatuses(owner, repo, ref string) ([]*gogh.RepoStatus, error) {
+	allStatuses := make([]*gogh.RepoStatus, 0)
+
+	err := c.do(func(aroundCtx aroundContext) (func(), *gogh.Response, error) {
+		statuses, response, e := c.gh.Repositories.ListStatuses(context.Background(), owner, repo, ref, listOpts(aroundCtx))
+		return func() {
+			allStatuses = append(allStatuses, statuses...)
+		}, response, c.checkHTTPCode(response, e)
+	})
+
+	return allStatuses, err
+}
+
+// ListLanguages lists the languages of the repository together with the number of bytes of code written in them.
+func (c *client) ListLanguages(owner, repo string) (map[string]int, error) {
+	var repoLanguages map[string]int
+
+	err := c.do(func(aroundCtx aroundContext) (func(), *gogh.Response, error) {
+		languages, response, e := c.gh.Repositories.ListLanguages(context.Background(), owner, repo)
+		return func() {
+			repoLanguages = languages
+		}, response, c.checkHTTPCode(response, e)
+	})
+
+	return repoLanguages, err
+}
+
+// GetIssue retrieves a single issue (or a pull request as it's an issue as well). Returns nil when there is no such issue.
+func (c *client) GetIssue(owner, repo string, number int) (*gogh.Issue, error) {
+	var issue *gogh.Issue
+
+	err := c.do(func(aroundContext aroundContext) (func(), *gogh.Response, error) {
+		retrieved, response, e := c.gh.Issues.Get(context.Background(), owner, repo, number)
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return func() {}, response, nil
+		}
+		return func() {
+			issue = retrieved
+		}, response, c.checkHTTPCode(response, e)
+	})
+
+	return issue, err
+}
+
 // ListIssueComments lists all comments on the specified issue.
 func (c *client) ListIssueComments(issue scm.RepositoryIssue) ([]*gogh.IssueComment, error) {
 	allComments := make([]*gogh.IssueComment, 0)
@@ -148,6 +308,20 @@ func (c *client) ListIssueComments(issue scm.RepositoryIssue) ([]*gogh.IssueComm
 	return allComments, err
 }
 
+// ListIssueEvents lists all events (such as labeling) of the specified issue.
+func (c *client) ListIssueEvents(issue scm.RepositoryIssue) ([]*gogh.IssueEvent, error) {
+	allEvents := make([]*gogh.IssueEvent, 0)
+
+	err := c.do(func(aroundCtx aroundContext) (func(), *gogh.Response, error) {
+		events, response, e := c.gh.Issues.ListIssueEvents(context.Background(), issue.Owner, issue.RepoName, issue.Number, listOpts(aroundCtx))
+		return func() {
+			allEvents = append(allEvents, events...)
+		}, response, c.checkHTTPCode(response, e)
+	})
+
+	return allEvents, err
+}
+
 // CreateIssueComment creates a new comment on the specified issue.
 func (c *client) CreateIssueComment(issue scm.RepositoryIssue, commentMsg *string) error {
 	comment := &gogh.IssueComment{
@@ -174,6 +348,29 @@ func (c *client) EditIssueComment(issue scm.RepositoryIssue, commentID int64, co
 	return err
 }
 
+// CreateIssueCommentReaction adds a reaction (e.g. "+1" or "eyes") to an already existing comment in the given issue.
+func (c *client) CreateIssueCommentReaction(issue scm.RepositoryIssue, commentID int64, reaction string) error {
+	err := c.do(func(aroundContext aroundContext) (func(), *gogh.Response, error) {
+		_, response, e :=
+			c.gh.Reactions.CreateIssueCommentReaction(context.Background(), issue.Owner, issue.RepoName, commentID, reaction)
+		return func() {}, response, c.checkHTTPCode(response, e)
+	})
+
+	return err
+}
+
+// CreatePullRequestCommentReaction adds a reaction (e.g. "+1" or "eyes") to an already existing review comment
+// in the given pull request.
+func (c *client) CreatePullRequestCommentReaction(issue scm.RepositoryIssue, commentID int64, reaction string) error {
+	err := c.do(func(aroundContext aroundContext) (func(), *gogh.Response, error) {
+		_, response, e :=
+			c.gh.Reactions.CreatePullRequestCommentReaction(context.Background(), issue.Owner, issue.RepoName, commentID, reaction)
+		return func() {}, response, c.checkHTTPCode(response, e)
+	})
+
+	return err
+}
+
 // CreateStatus creates a new status for a repository at the specified reference represented by a RepositoryChange
 func (c *client) CreateStatus(change scm.RepositoryChange, repoStatus *gogh.RepoStatus) error {
 	err := c.do(func(aroundContext aroundContext) (func(), *gogh.Response, error) {
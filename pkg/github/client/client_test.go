@@ -0,0 +1,128 @@
+package ghclient_test
+
+import (
+	"fmt"
+	"strings"
+
+	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
+	. "github.com/arquillian/ike-prow-plugins/pkg/internal/test"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	gogh "github.com/google/go-github/v41/github"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+var _ = Describe("Listing changed files", func() {
+
+	client := ghclient.NewClient(gogh.NewClient(nil), log.NewTestLogger())
+	client.RegisterAroundFunctions(ghclient.NewPaginationChecker())
+
+	pullRequest := func(changedFiles int) *gogh.PullRequest {
+		return &gogh.PullRequest{
+			Number:       gogh.Int(2),
+			ChangedFiles: gogh.Int(changedFiles),
+			Base: &gogh.PullRequestBranch{Repo: &gogh.Repository{
+				Name:  gogh.String("wfswarm-booster-pipeline-test"),
+				Owner: &gogh.User{Login: gogh.String("bartoszmajsak")},
+			}},
+		}
+	}
+
+	mockFiles := func(count int) {
+		files := make([]string, count)
+		for i := range files {
+			files[i] = fmt.Sprintf(`{"filename":"src/main/java/File%d.java","status":"added","additions":1,"deletions":0}`, i)
+		}
+		gock.New("https://api.github.com").
+			Get("/repos/bartoszmajsak/wfswarm-booster-pipeline-test/pulls/2/files").
+			Reply(200).
+			BodyString("[" + strings.Join(files, ",") + "]")
+	}
+
+	BeforeEach(func() {
+		defer gock.OffAll()
+	})
+
+	AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+	It("should list all changed files of the pull request", func() {
+		// given
+		mockFiles(3)
+
+		// when
+		files, err := client.ListChangedFiles(pullRequest(3))
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(files).To(HaveLen(3))
+	})
+
+	It("should return listed files together with an error when the list is truncated", func() {
+		// given
+		mockFiles(ghclient.PullRequestFilesLimit)
+
+		// when
+		files, err := client.ListChangedFiles(pullRequest(4200))
+
+		// then
+		Expect(files).To(HaveLen(ghclient.PullRequestFilesLimit))
+		Ω(err).Should(Equal(&ghclient.TruncatedFilesError{Listed: 3000, Total: 4200}))
+		Ω(err).Should(MatchError("the list of changed files has been truncated to 3000 of 4200 files"))
+	})
+
+	It("should not consider the list truncated when the pull request changes exactly the maximum number of files", func() {
+		// given
+		mockFiles(ghclient.PullRequestFilesLimit)
+
+		// when
+		files, err := client.ListChangedFiles(pullRequest(ghclient.PullRequestFilesLimit))
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(files).To(HaveLen(ghclient.PullRequestFilesLimit))
+	})
+})
+
+var _ = Describe("Getting an issue", func() {
+
+	client := ghclient.NewClient(gogh.NewClient(nil), log.NewTestLogger())
+	client.RegisterAroundFunctions(ghclient.NewPaginationChecker())
+
+	BeforeEach(func() {
+		defer gock.OffAll()
+	})
+
+	AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+	It("should retrieve the issue", func() {
+		// given
+		gock.New("https://api.github.com").
+			Get("/repos/bartoszmajsak/wfswarm-booster-pipeline-test/issues/1").
+			Reply(200).
+			BodyString(`{"number":1,"title":"Add dummy response","state":"open"}`)
+
+		// when
+		issue, err := client.GetIssue("bartoszmajsak", "wfswarm-booster-pipeline-test", 1)
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(issue.GetTitle()).To(Equal("Add dummy response"))
+		Expect(issue.GetState()).To(Equal("open"))
+	})
+
+	It("should return nil without an error when the issue doesn't exist", func() {
+		// given
+		gock.New("https://api.github.com").
+			Get("/repos/bartoszmajsak/wfswarm-booster-pipeline-test/issues/404").
+			Reply(404).
+			BodyString(`{"message":"Not Found"}`)
+
+		// when
+		issue, err := client.GetIssue("bartoszmajsak", "wfswarm-booster-pipeline-test", 404)
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(issue).To(BeNil())
+	})
+})
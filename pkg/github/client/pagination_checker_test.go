@@ -8,6 +8,7 @@ import (
 	gogh "github.com/google/go-github/v41/github"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
 	gock "gopkg.in/h2non/gock.v1"
 )
 
@@ -71,9 +72,9 @@ var _ = Describe("Pagination checker", func() {
 			Expect(gock.GetUnmatchedRequests()).To(BeEmpty())
 			Expect(files).To(HaveLen(3))
 			Expect(files).To(ConsistOf(
-				newChangedFile("Jenkinsfile", "modified", 3, 3),
-				newChangedFile("README.adoc", "modified", 2, 2),
-				newChangedFile("src/test/java/io/openshift/booster/NewTest.java", "added", 66, 0),
+				beChangedFile(newChangedFile("Jenkinsfile", "modified", 3, 3), "@@ -1,6 +1,6 @@"),
+				beChangedFile(newChangedFile("README.adoc", "modified", 2, 2), "@@ -1,6 +1,6 @@"),
+				beChangedFile(newChangedFile("src/test/java/io/openshift/booster/NewTest.java", "added", 66, 0), "@@ -0,0 +1,66 @@"),
 			))
 
 		})
@@ -88,3 +89,14 @@ func newChangedFile(name, status string, additions, deletions int) scm.ChangedFi
 		Deletions: deletions,
 	}
 }
+
+func beChangedFile(expected scm.ChangedFile, patchStart string) types.GomegaMatcher {
+	withoutPatch := func(file scm.ChangedFile) scm.ChangedFile {
+		file.Patch = ""
+		return file
+	}
+	patch := func(file scm.ChangedFile) string {
+		return file.Patch
+	}
+	return SatisfyAll(WithTransform(withoutPatch, Equal(expected)), WithTransform(patch, HavePrefix(patchStart)))
+}
@@ -33,3 +33,13 @@ func (s *CommentService) AddComment(commentMsg *string) error {
 func (s *CommentService) EditComment(commentID int64, commentMsg *string) error {
 	return s.Client.EditIssueComment(s.Issue, commentID, commentMsg)
 }
+
+// AddReaction adds a reaction to an already existing comment
+func (s *CommentService) AddReaction(commentID int64, reaction string) error {
+	return s.Client.CreateIssueCommentReaction(s.Issue, commentID, reaction)
+}
+
+// AddReviewCommentReaction adds a reaction to an already existing review comment
+func (s *CommentService) AddReviewCommentReaction(commentID int64, reaction string) error {
+	return s.Client.CreatePullRequestCommentReaction(s.Issue, commentID, reaction)
+}
@@ -28,6 +28,14 @@ const (
 	StatusFailure = "failure"
 )
 
+// These are reactions used to acknowledge comment commands.
+const (
+	ReactionEyes       = "eyes"
+	ReactionThumbsUp   = "+1"
+	ReactionThumbsDown = "-1"
+	ReactionConfused   = "confused"
+)
+
 // These are the possible actions for the Pull Request Event Type
 const (
 	ActionLabeled   = "labeled"
@@ -35,6 +43,8 @@ const (
 )
 
 const (
-	IssueComment = EventType("issue_comment") // nolint
-	PullRequest  = EventType("pull_request")  // nolint
+	IssueComment             = EventType("issue_comment")               // nolint
+	PullRequest              = EventType("pull_request")                // nolint
+	PullRequestReview        = EventType("pull_request_review")         // nolint
+	PullRequestReviewComment = EventType("pull_request_review_comment") // nolint
 )
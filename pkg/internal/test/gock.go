@@ -13,6 +13,20 @@ func EnsureGockRequestsHaveBeenMatched() {
 	gomega.Expect(gock.GetUnmatchedRequests()).To(gomega.BeEmpty(), "Have no unmatched requests")
 }
 
+// AnyRepositoryWrittenIn mocks the languages API of any repository to respond with the given languages. The mock is
+// persisted, so it responds to any number of requests until gock is turned off
+func AnyRepositoryWrittenIn(languages ...string) {
+	repoLanguages := make(map[string]int, len(languages))
+	for _, language := range languages {
+		repoLanguages[language] = 1024
+	}
+	gock.New("https://api.github.com").
+		Get("/repos/[^/]+/[^/]+/languages$").
+		Persist().
+		Reply(200).
+		JSON(repoLanguages)
+}
+
 // NonExistingRawGitHubFiles mocks any matching path suffix when calling "https://raw.githubusercontent.com" with 404 response
 func NonExistingRawGitHubFiles(pathSuffixes ...string) {
 	for _, pathSuffix := range pathSuffixes {
@@ -81,6 +81,7 @@ func (l *MockPrBuilderLoader) LoadedFromDefaultStruct() *MockPrBuilder {
 				Owner: createGhUser("bartoszmajsak"),
 				Name:  utils.String("wfswarm-booster-pipeline-test"),
 			},
+			SHA: utils.String("3c2de4f2aeeb9b4bb1a0af2ce2b2d41d5b6cb3a6"),
 		},
 		Head: &gogh.PullRequestBranch{
 			SHA: utils.String("df8e5cd15f05e1d975e17df322b9babedccf0a1a"),
@@ -113,6 +114,12 @@ func (b *MockPrBuilder) WithSize(size int) *MockPrBuilder {
 	return b
 }
 
+// WithCommitsCount sets the given number of commits to the mocked pull request
+func (b *MockPrBuilder) WithCommitsCount(count int) *MockPrBuilder {
+	b.pullRequest.Commits = &count
+	return b
+}
+
 // Create initializes the gock mocks based on the predefined information
 func (b *MockPrBuilder) Create() *PrMock {
 	for _, mock := range b.mockCreators {
@@ -168,6 +175,32 @@ func (pr *PrMock) CreateCommentEvent(userCreator SenderCreator, content, action
 	}
 }
 
+// CreateReviewEvent based on the mocked PR information creates a PullRequestReviewEvent
+func (pr *PrMock) CreateReviewEvent(userCreator SenderCreator, content, action string) *gogh.PullRequestReviewEvent {
+	return &gogh.PullRequestReviewEvent{
+		Action:      utils.String(action),
+		PullRequest: pr.PullRequest,
+		Review: &gogh.PullRequestReview{
+			Body: utils.String(content),
+		},
+		Repo:   pr.PullRequest.Base.Repo,
+		Sender: userCreator(pr.PullRequest),
+	}
+}
+
+// CreateReviewCommentEvent based on the mocked PR information creates a PullRequestReviewCommentEvent
+func (pr *PrMock) CreateReviewCommentEvent(userCreator SenderCreator, content, action string) *gogh.PullRequestReviewCommentEvent {
+	return &gogh.PullRequestReviewCommentEvent{
+		Action:      utils.String(action),
+		PullRequest: pr.PullRequest,
+		Comment: &gogh.PullRequestComment{
+			Body: utils.String(content),
+		},
+		Repo:   pr.PullRequest.Base.Repo,
+		Sender: userCreator(pr.PullRequest),
+	}
+}
+
 func createGhUser(name string) *gogh.User {
 	return &gogh.User{Login: utils.String(name)}
 }
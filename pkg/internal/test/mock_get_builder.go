@@ -48,6 +48,15 @@ func (b *MockPrBuilder) mockComments(content string, options ...RequestOption) {
 	b.addMockCreator(b.mockGetForPR("issues", "/comments", content, options...))
 }
 
+// WithIssueEvents sets the given payload containing issue events (such as labeling) to the mocked PR
+func (b *MockPrBuilder) WithIssueEvents(jsonContent string, options ...RequestOption) *MockPrBuilder {
+	if len(options) == 0 {
+		options = []RequestOption{perPage100, page1}
+	}
+	b.addMockCreator(b.mockGetForPR("issues", "/events", jsonContent, options...))
+	return b
+}
+
 // WithReviews sets the given payload containing list of reviews to the mocked PR
 func (b *MockPrBuilder) WithReviews(jsonContent string, options ...RequestOption) *MockPrBuilder {
 	b.mockReviews(jsonContent, options...)
@@ -67,6 +76,41 @@ func (b *MockPrBuilder) mockReviews(content string, options ...RequestOption) {
 	b.addMockCreator(b.mockGetForPR("pulls", "/reviews", content, options...))
 }
 
+// WithCommits sets the given payload containing list of commits to the mocked PR
+func (b *MockPrBuilder) WithCommits(jsonContent string, options ...RequestOption) *MockPrBuilder {
+	if len(options) == 0 {
+		options = []RequestOption{perPage100, page1}
+	}
+	b.addMockCreator(b.mockGetForPR("pulls", "/commits", jsonContent, options...))
+	return b
+}
+
+// WithComparedFiles sets the given payload containing changed files as a result of the comparison of the given base
+// commit with the head of the mocked PR
+func (b *MockPrBuilder) WithComparedFiles(base, jsonContent string) *MockPrBuilder {
+	b.addMockCreator(func(builder *MockPrBuilder) {
+		path := fmt.Sprintf("%s/compare/%s...%s", builder.baseRepoPath(), base, *builder.pullRequest.Head.SHA)
+		builder.baseGetMock(path, `{"files":`+jsonContent+`}`, perPage100, page1)
+	})
+	return b
+}
+
+// WithStatuses sets the given payload containing list of statuses of the given commit of the mocked PR
+func (b *MockPrBuilder) WithStatuses(sha, jsonContent string) *MockPrBuilder {
+	b.addMockCreator(func(builder *MockPrBuilder) {
+		builder.baseGetMock(fmt.Sprintf("%s/commits/%s/statuses", builder.baseRepoPath(), sha), jsonContent, perPage100, page1)
+	})
+	return b
+}
+
+// WithLanguages sets the given payload containing languages of the base repository of the mocked PR
+func (b *MockPrBuilder) WithLanguages(jsonContent string) *MockPrBuilder {
+	b.addMockCreator(func(builder *MockPrBuilder) {
+		builder.baseGetMock(builder.baseRepoPath()+"/languages", jsonContent)
+	})
+	return b
+}
+
 // WithLabels sets the given payload containing list of labels to the mocked PR
 func (b *MockPrBuilder) WithLabels(labelNames ...string) *MockPrBuilder {
 	for _, labelName := range labelNames {
@@ -110,6 +154,13 @@ func Admin(name string) func(pr *gogh.PullRequest) *GhUser {
 	}
 }
 
+// Collaborator creates an instance of GhUser with the given repository permission level
+func Collaborator(name, permission string) func(pr *gogh.PullRequest) *GhUser {
+	return func(pr *gogh.PullRequest) *GhUser {
+		return &GhUser{name, permission}
+	}
+}
+
 // PrCreator creates an instance of GhUser with the given name and the name sets as PR's creator login
 func PrCreator(name string) func(pr *gogh.PullRequest) *GhUser {
 	return func(pr *gogh.PullRequest) *GhUser {
@@ -153,9 +204,69 @@ func (b *MockPrBuilder) mockGetForCollaborators(user, suffix, body string, optio
 	b.baseGetMock(fmt.Sprintf("%s/collaborators/%s", b.baseRepoPath(), user)+suffix, body, options...)
 }
 
+// WithTeamMembers mocks the given users as active members of the given organization team
+func (b *MockPrBuilder) WithTeamMembers(org, teamSlug string, userNames ...string) *MockPrBuilder {
+	for _, userName := range userNames {
+		path := fmt.Sprintf("/orgs/%s/teams/%s/memberships/%s", org, teamSlug, userName)
+		b.addMockCreator(func(builder *MockPrBuilder) {
+			builder.baseGetMock(path, `{"state":"active","role":"member"}`)
+		})
+	}
+	return b
+}
+
+// WithoutTeamMembers mocks the given users as not being members of the given organization team
+func (b *MockPrBuilder) WithoutTeamMembers(org, teamSlug string, userNames ...string) *MockPrBuilder {
+	for _, userName := range userNames {
+		path := fmt.Sprintf("/orgs/%s/teams/%s/memberships/%s", org, teamSlug, userName)
+		b.addMockCreator(func(builder *MockPrBuilder) {
+			baseGockMock(func(request *gock.Request) { request.Get(path + "$") }).
+				Reply(404).
+				BodyString(`{"message":"Not Found"}`)
+		})
+	}
+	return b
+}
+
+// WithIssue sets the given payload representing the issue with the given number in the given repository
+// (in the owner/repo format). When the repository is empty then the base repository of the mocked PR is used
+func (b *MockPrBuilder) WithIssue(repository string, number int, jsonContent string) *MockPrBuilder {
+	b.addMockCreator(func(builder *MockPrBuilder) {
+		builder.baseGetMock(builder.issuePath(repository, number), jsonContent)
+	})
+	return b
+}
+
+// WithoutIssue sets that there is no issue with the given number in the given repository (in the owner/repo format).
+// When the repository is empty then the base repository of the mocked PR is used
+func (b *MockPrBuilder) WithoutIssue(repository string, number int) *MockPrBuilder {
+	b.addMockCreator(func(builder *MockPrBuilder) {
+		path := builder.issuePath(repository, number)
+		baseGockMock(func(request *gock.Request) { request.Get(path + "$") }).
+			Reply(404).
+			BodyString(`{"message":"Not Found"}`)
+	})
+	return b
+}
+
+func (b *MockPrBuilder) issuePath(repository string, number int) string {
+	if repository == "" {
+		return fmt.Sprintf("%s/issues/%d", b.baseRepoPath(), number)
+	}
+	return fmt.Sprintf("/repos/%s/issues/%d", repository, number)
+}
+
 // RequestOption add a option to a associated request
 type RequestOption = func(request *gock.Request)
 
+// Times makes the associated request match the given number of calls, e.g. when the same resource is loaded
+// by independent parts of the plugin
+func Times(num int) RequestOption {
+	return func(request *gock.Request) {
+		request.Times(num)
+	}
+}
+
 var perPage100 = func(request *gock.Request) {
 	request.MatchParam("per_page", "100")
 }
@@ -219,6 +330,28 @@ func ConfigYaml(content string) func(builder *MockPrBuilder) {
 	}
 }
 
+// BaseConfigYml creates a representation of a config file with yml suffix located at the base revision of the PR
+func BaseConfigYml(content string) func(builder *MockPrBuilder) {
+	return func(builder *MockPrBuilder) {
+		builder.WithBaseRawFile(ghservice.ConfigHome+builder.pluginName+".yml", content)
+	}
+}
+
+// WithoutBaseConfigFiles sets that the base revision of the associated mocked PR shouldn't contain any configuration
+// file for the plugin
+func (b *MockPrBuilder) WithoutBaseConfigFiles() *MockPrBuilder {
+	for _, config := range []string{"%s.yml", "%s.yaml"} {
+		path := ghservice.ConfigHome + fmt.Sprintf(config, b.pluginName)
+		b.addMockCreator(func(builder *MockPrBuilder) {
+			pr := builder.pullRequest
+			gock.New("https://raw.githubusercontent.com").
+				Path(fmt.Sprintf("%s/%s/%s/%s", *pr.Base.Repo.Owner.Login, *pr.Base.Repo.Name, *pr.Base.SHA, path)).
+				Reply(404)
+		})
+	}
+	return b
+}
+
 // ConfigYml creates a representation of a config file with yml suffix
 func ConfigYml(content string) func(builder *MockPrBuilder) {
 	return func(builder *MockPrBuilder) {
@@ -248,6 +381,23 @@ func (b *MockPrBuilder) WithRawFile(fileName, content string) *MockPrBuilder {
 	return b
 }
 
+// WithCodeOwners sets that the base revision of the associated mocked PR should contain .github/CODEOWNERS file with the given content
+func (b *MockPrBuilder) WithCodeOwners(content string) *MockPrBuilder {
+	return b.WithBaseRawFile(".github/CODEOWNERS", content)
+}
+
+// WithBaseRawFile sets that the base revision of the associated mocked PR should contain the given file
+func (b *MockPrBuilder) WithBaseRawFile(fileName, content string) *MockPrBuilder {
+	b.addMockCreator(func(builder *MockPrBuilder) {
+		pr := builder.pullRequest
+		gock.New("https://raw.githubusercontent.com").
+			Path(fmt.Sprintf("%s/%s/%s/%s", *pr.Base.Repo.Owner.Login, *pr.Base.Repo.Name, *pr.Base.SHA, fileName)).
+			Reply(200).
+			BodyString(content)
+	})
+	return b
+}
+
 func (b *MockPrBuilder) getBaseRawFilesMock(path string) *gock.Request {
 	pr := b.pullRequest
 	return gock.New("https://raw.githubusercontent.com").
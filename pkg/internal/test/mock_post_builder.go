@@ -73,6 +73,31 @@ func NoComment() MockCreator {
 		basePostCommentMock(builder)(nil)
 	}
 }
+
+// Reaction creates a gock matcher to check that there is a Post with the given reaction for the given comment id
+func Reaction(commentID int64, reaction string) MockCreator {
+	return func(builder *MockPrBuilder) {
+		path := fmt.Sprintf("%s/issues/comments/%d/reactions", builder.baseRepoPath(), commentID)
+		basePostMock(path)(SoftlySatisfyAll(HaveContent(reaction)))
+	}
+}
+
+// NoReaction creates a gock matcher to check that there is no reaction sent for the given comment id
+func NoReaction(commentID int64) MockCreator {
+	return func(builder *MockPrBuilder) {
+		path := fmt.Sprintf("%s/issues/comments/%d/reactions", builder.baseRepoPath(), commentID)
+		basePostMock(path)(nil)
+	}
+}
+
+// ReviewCommentReaction creates a gock matcher to check that the given reaction is sent for the review comment with the given id
+func ReviewCommentReaction(commentID int64, reaction string) MockCreator {
+	return func(builder *MockPrBuilder) {
+		path := fmt.Sprintf("%s/pulls/comments/%d/reactions", builder.baseRepoPath(), commentID)
+		basePostMock(path)(SoftlySatisfyAll(HaveContent(reaction)))
+	}
+}
+
 func basePostCommentMock(builder *MockPrBuilder) func(mather SoftMatcher) {
 	path := fmt.Sprintf("%s/issues/%d/comments", builder.baseRepoPath(), *builder.pullRequest.Number)
 	return basePostMock(path)
@@ -107,3 +107,12 @@ func HaveBodyThatContains(content string) SoftMatcher {
 		gomega.ContainSubstring(content),
 		"body")
 }
+
+// HaveContent gets "content" key from map[string]interface{} and compares its value with expectedContent
+// This matcher is used to verify reaction content sent to GitHub API
+func HaveContent(expectedContent string) SoftMatcher {
+	return TransformWithName(
+		func(s map[string]interface{}) interface{} { return s["content"] },
+		gomega.Equal(expectedContent),
+		"content")
+}
@@ -8,6 +8,7 @@ import (
 
 	"strconv"
 
+	"github.com/arquillian/ike-prow-plugins/pkg/audit"
 	probeshandler "github.com/arquillian/ike-prow-plugins/pkg/probes-handler"
 	"github.com/arquillian/ike-prow-plugins/pkg/utils"
 	"k8s.io/test-infra/prow/pluginhelp/externalplugins"
@@ -39,6 +40,9 @@ var (
 	pluginBotName       = flag.String("bot-name", "alien-ike", "Bot Name used for the plugins.")
 	httpAddress         = flag.String("http.address", "0.0.0.0:"+strconv.Itoa(*port), "Http address at which prow server binds")
 	metricsHttpAddress  = flag.String("metrics.http.address", "0.0.0.0:"+strconv.Itoa(*port), "Address at which /metrics endpoint will be mounted.")
+	auditFile           = flag.String("audit-file", "", "Path to the file the audit records of triggered commands are appended to (JSON lines).")
+	auditTokenFile      = flag.String("audit-token-file", "", "Path to the file containing the token authorizing requests to /audit endpoint. Enables the endpoint when used together with --audit-file.")
+	auditEndpoint       = flag.String("audit-endpoint", "", "URL of the HTTP endpoint the audit records of triggered commands are sent to.")
 )
 
 // DocumentationURL is a link to arquillian ike-prow-plugins documentation
@@ -99,6 +103,8 @@ func InitPlugin(pluginName string, newEventHandler EventHandlerCreator, newServe
 
 	externalplugins.ServeExternalPluginHelp(http.DefaultServeMux, logger, helpProvider)
 
+	configureAudit(pluginName, logger)
+
 	if *httpAddress == *metricsHttpAddress {
 		http.Handle("/metrics", promhttp.Handler())
 	} else {
@@ -138,6 +144,39 @@ func configureLogger(pluginName string) *logrus.Entry {
 	return logger
 }
 
+func configureAudit(pluginName string, logger *logrus.Entry) {
+	var sinks audit.MultiSink
+	if *auditFile != "" {
+		fileSink := audit.NewFileSink(*auditFile)
+		sinks = append(sinks, fileSink)
+		configureAuditEndpoint(fileSink, logger)
+	}
+	if *auditEndpoint != "" {
+		if _, err := url.Parse(*auditEndpoint); err != nil {
+			logger.WithError(err).Fatalf("Must specify a valid --audit-endpoint URL.")
+		}
+		sinks = append(sinks, audit.NewHTTPSink(*auditEndpoint))
+	}
+	if len(sinks) > 0 {
+		audit.Register(pluginName, sinks)
+	}
+}
+
+func configureAuditEndpoint(querier audit.Querier, logger *logrus.Entry) {
+	if *auditTokenFile == "" {
+		logger.Infof("no --audit-token-file specified. /audit endpoint is disabled")
+		return
+	}
+	token, err := utils.LoadSecret(*auditTokenFile)
+	if err != nil {
+		logger.WithError(err).Fatalf("unable to load audit token from %q", *auditTokenFile)
+	}
+	if len(token) == 0 {
+		logger.Fatalf("audit token loaded from %q is empty", *auditTokenFile)
+	}
+	http.Handle("/audit", audit.NewQueryHandler(querier, token, logger))
+}
+
 func logErrors(errors []error, logger *logrus.Entry, errMessage string) {
 	if len(errors) > 0 {
 		errLog := logger
@@ -1,6 +1,7 @@
 package prsanitizer
 
 import (
+	"strconv"
 	"strings"
 
 	"regexp"
@@ -13,6 +14,9 @@ import (
 	gogh "github.com/google/go-github/v41/github"
 )
 
+// minLengthOption is a name of the option of description-length check setting the minimal length of the description
+const minLengthOption = "min_length"
+
 var (
 	issueLinkRegexp = regexp.MustCompile(`(?i)(close|closes|closed|fix|fixes|fixed|resolve|resolves|resolved)[\s]*[:]?[\s]*[\w-\/]*#[\d]+`)
 	defaultTypes    = []string{"chore", "docs", "feat", "fix", "refactor", "style", "test"}
@@ -21,9 +25,9 @@ var (
 const (
 	// TitleFailureMessage is a message used in GH Status as description when the PR title does not follow semantic message style
 	TitleFailureMessage = "#### Semantic title\nThe PR title `%s` does not conform with the " +
-		"[semantic message](https://seesparkbox.com/foundry/semantic_commit_messages) style. " +
+		"[Conventional Commits](https://www.conventionalcommits.org) style `type(scope)!: subject` - %s:\n\n```\n%s\n```\n\n" +
 		"The semantic message makes your changelog and git history clean. " +
-		"Please, edit the PR by prefixing it with one of the type prefixes that are valid for your repository: %s."
+		"Please, edit the PR title. The type prefixes that are valid for your repository are: %s."
 
 	// DescriptionLengthShortMessage is a status message that is used in case of short PR description.
 	DescriptionLengthShortMessage = "#### PR description length\nThe PR description is too short - it is expected that " +
@@ -35,56 +39,64 @@ const (
 	IssueLinkMissingMessage = "#### Issue link\nThe PR description is missing any issue link that would be used with any of the " +
 		"[GitHub keywords](https://help.github.com/articles/closing-issues-using-keywords/). " +
 		"Having it in the PR description ensures that the issue is automatically closed when the PR is merged."
-)
 
-type check func(pr *gogh.PullRequest, config PluginConfiguration, logger log.Logger) string
-
-func executeChecks(pr *gogh.PullRequest, config PluginConfiguration, logger log.Logger) []string {
-	checks := []check{CheckSemanticTitle, CheckDescriptionLength, CheckIssueLinkPresence}
-	var messages []string
-	for _, check := range checks {
-		msg := check(pr, config, logger)
-		if msg != "" {
-			messages = append(messages, msg)
-		}
-	}
-	return messages
-}
+	// IssueReferenceMissingMessage is a status message that is used in case of missing issue reference when there are
+	// other than GitHub issue links accepted.
+	IssueReferenceMissingMessage = "#### Issue link\nThe PR description is missing any reference to the issue the PR is related to. " +
+		"The accepted references are:\n\n%s"
+)
 
-// CheckSemanticTitle checks if the given PR contains semantic title
+// CheckSemanticTitle checks if the given PR contains semantic title following the Conventional Commits style
 func CheckSemanticTitle(pr *gogh.PullRequest, config PluginConfiguration, logger log.Logger) string {
 	change := ghservice.NewRepositoryChangeForPR(pr)
-	prefixes := GetValidTitlePrefixes(config)
-	isTitleWithValidType := HasTitleWithValidType(prefixes, *pr.Title)
+	rules := config.titleRules()
+	_, err := ParseTitle(pr.GetTitle(), rules)
 
-	if !isTitleWithValidType {
-		if prefix, ok := wip.GetWorkInProgressPrefix(*pr.Title, wip.LoadConfiguration(logger, change)); ok {
-			trimmedTitle := strings.TrimPrefix(*pr.Title, prefix)
-			isTitleWithValidType = HasTitleWithValidType(prefixes, trimmedTitle)
+	if err != nil {
+		if prefix, ok := wip.GetWorkInProgressPrefix(pr.GetTitle(), wip.LoadConfiguration(logger, change)); ok {
+			_, err = ParseTitle(strings.TrimPrefix(pr.GetTitle(), prefix), rules)
 		}
 	}
-	if !isTitleWithValidType {
-		allPrefixes := "`" + strings.Join(prefixes, "`, `") + "`"
-		return fmt.Sprintf(TitleFailureMessage, pr.GetTitle(), allPrefixes)
+	if titleErr, ok := err.(*TitleError); ok {
+		return fmt.Sprintf(TitleFailureMessage, pr.GetTitle(), titleErr.Message, titleErr.Pointer(), inlineCodeList(rules.Types))
 	}
 	return ""
 }
 
-// CheckDescriptionLength  checks if the given PR's description contains enough number of arguments
+// CheckDescriptionLength  checks if the given PR's description contains enough number of arguments.
+// The minimal length can be set also by min_length option of the check
 func CheckDescriptionLength(pr *gogh.PullRequest, config PluginConfiguration, logger log.Logger) string {
-	actualLength := len(strings.TrimSpace(issueLinkRegexp.ReplaceAllString(pr.GetBody(), "")))
-	if actualLength < config.DescriptionContentLength {
-		return fmt.Sprintf(DescriptionLengthShortMessage, config.DescriptionContentLength, actualLength)
+	minLength := config.DescriptionContentLength
+	if option := config.checkOption(DescriptionLengthCheck, minLengthOption); option != "" {
+		if length, err := strconv.Atoi(option); err == nil {
+			minLength = length
+		} else {
+			logger.Warnf("invalid %s option %q of %q check, %d is used instead", minLengthOption, option, DescriptionLengthCheck, minLength)
+		}
+	}
+	description := pr.GetBody()
+	for _, matcher := range config.IssueReferences.matchers(logger) {
+		description = matcher.ReplaceAllString(description, "")
+	}
+	actualLength := len(strings.TrimSpace(description))
+	if actualLength < minLength {
+		return fmt.Sprintf(DescriptionLengthShortMessage, minLength, actualLength)
 	}
 	return ""
 }
 
-// CheckIssueLinkPresence checks if the given PR's description contains an issue link
+// CheckIssueLinkPresence checks if the given PR's description contains an issue link or any other of the configured
+// issue references
 func CheckIssueLinkPresence(pr *gogh.PullRequest, config PluginConfiguration, logger log.Logger) string {
-	if !issueLinkRegexp.MatchString(pr.GetBody()) {
-		return IssueLinkMissingMessage
+	for _, matcher := range config.IssueReferences.matchers(logger) {
+		if matcher.MatchString(pr.GetBody()) {
+			return ""
+		}
 	}
-	return ""
+	if config.IssueReferences.external() {
+		return fmt.Sprintf(IssueReferenceMissingMessage, config.IssueReferences.description())
+	}
+	return IssueLinkMissingMessage
 }
 
 // GetValidTitlePrefixes returns list of valid prefixes
@@ -100,14 +112,8 @@ func GetValidTitlePrefixes(config PluginConfiguration) []string {
 	return prefixes
 }
 
-// HasTitleWithValidType checks if title prefix conforms with semantic message style.
+// HasTitleWithValidType checks if title conforms with semantic message style using any of the given types.
 func HasTitleWithValidType(prefixes []string, title string) bool {
-	pureTitle := strings.TrimSpace(title)
-	for _, prefix := range prefixes {
-		prefixRegexp := regexp.MustCompile(`(?i)^` + prefix + `(:| |\()+`)
-		if prefixRegexp.MatchString(pureTitle) {
-			return true
-		}
-	}
-	return false
+	_, err := ParseTitle(title, TitleRules{Types: prefixes})
+	return err == nil
 }
@@ -0,0 +1,116 @@
+package prsanitizer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	gogh "github.com/google/go-github/v41/github"
+)
+
+// rejectAutosquashOption is a name of the option of commit-messages check rejecting fixup! and squash! commits
+const rejectAutosquashOption = "reject_autosquash"
+
+// CommitMessagesFailureMessage is a status message that is used when any of the commit messages of the PR doesn't
+// conform with the rules
+const CommitMessagesFailureMessage = "#### Commit messages\nThe following commits of the PR don't conform with " +
+	"the [Conventional Commits](https://www.conventionalcommits.org) style or are meant to be squashed:\n\n%s\n\n" +
+	"The commits are merged as they are, so their messages end up in the git history. " +
+	"Please, reword or squash the commits (e.g. using `git rebase -i --autosquash`) and force push the branch."
+
+// CommitMessagesUnverifiedMessage is a status message that is used when the commits of the PR can't be listed
+const CommitMessagesUnverifiedMessage = "#### Commit messages\nThe commits of the PR couldn't be listed, so their messages " +
+	"haven't been verified. Please, trigger the verification again by commenting `/run pr-sanitizer`."
+
+// CommitMessagesTruncatedMessage is a part of the status message that is used when the PR contains more commits than
+// GitHub lists
+const CommitMessagesTruncatedMessage = "The PR contains %s commits, but GitHub lists only the first %d of them, " +
+	"so the messages of the remaining commits haven't been verified. Please, squash the commits or split the PR."
+
+var autosquashPrefixes = []string{"fixup!", "squash!"}
+
+// CheckCommitMessages applies the title rules to the subject of each commit of the given PR. Merge commits are skipped
+// and so are fixup! and squash! commits - unless the reject_autosquash option of the check is set. When the commits
+// can't be listed (or only some of them can), then it's reported as well
+func CheckCommitMessages(client ghclient.Client, pr *gogh.PullRequest, config PluginConfiguration, logger log.Logger) string {
+	owner, repo := pr.GetBase().GetRepo().GetOwner().GetLogin(), pr.GetBase().GetRepo().GetName()
+	commits, err := client.ListPullRequestCommits(owner, repo, pr.GetNumber())
+	if err != nil {
+		logger.Errorf("failed to list commits of the pull request so the commit messages are not verified. cause: %s", err)
+		return CommitMessagesUnverifiedMessage
+	}
+
+	rejectAutosquash := false
+	if option := config.checkOption(CommitMessagesCheck, rejectAutosquashOption); option != "" {
+		if rejectAutosquash, err = strconv.ParseBool(option); err != nil {
+			logger.Warnf("invalid %s option %q of %q check, false is used instead", rejectAutosquashOption, option, CommitMessagesCheck)
+		}
+	}
+
+	rules := config.titleRules()
+	var offending []string
+	for _, commit := range commits {
+		if len(commit.Parents) > 1 {
+			continue
+		}
+		subject := commitSubject(commit)
+		if prefix, ok := autosquashPrefix(subject); ok {
+			if rejectAutosquash {
+				offending = append(offending, offendingCommit(commit, subject, fmt.Sprintf("the commit is marked by `%s` to be squashed", prefix)))
+			}
+			continue
+		}
+		if _, err := ParseTitle(subject, rules); err != nil {
+			offending = append(offending, offendingCommit(commit, subject, err.Error()))
+		}
+	}
+	report := ""
+	if len(offending) > 0 {
+		report = fmt.Sprintf(CommitMessagesFailureMessage, strings.Join(offending, "\n"))
+	}
+	if truncated := truncatedCommitsReport(pr, commits); truncated != "" {
+		if report == "" {
+			report = "#### Commit messages\n" + truncated
+		} else {
+			report += "\n\n" + truncated
+		}
+	}
+	return report
+}
+
+// truncatedCommitsReport says that the messages of some commits haven't been verified when the PR contains more
+// commits than GitHub lists. Returns an empty string otherwise
+func truncatedCommitsReport(pr *gogh.PullRequest, commits []*gogh.RepositoryCommit) string {
+	total := pr.GetCommits()
+	if len(commits) < ghclient.PullRequestCommitsLimit || (total != 0 && total <= len(commits)) {
+		return ""
+	}
+	count := "more than " + strconv.Itoa(len(commits))
+	if total > 0 {
+		count = strconv.Itoa(total)
+	}
+	return fmt.Sprintf(CommitMessagesTruncatedMessage, count, len(commits))
+}
+
+func commitSubject(commit *gogh.RepositoryCommit) string {
+	return strings.TrimSpace(strings.SplitN(commit.GetCommit().GetMessage(), "\n", 2)[0])
+}
+
+func autosquashPrefix(subject string) (string, bool) {
+	for _, prefix := range autosquashPrefixes {
+		if strings.HasPrefix(subject, prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+func offendingCommit(commit *gogh.RepositoryCommit, subject, problem string) string {
+	sha := commit.GetSHA()
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	return fmt.Sprintf("* %s `%s` - %s", sha, strings.Replace(subject, "`", "'", -1), problem)
+}
@@ -11,9 +11,59 @@ import (
 // It's unmarshaled from pr-sanitizer.yml configuration file
 type PluginConfiguration struct {
 	config.PluginConfiguration `yaml:",inline,omitempty"`
-	TypePrefix                 []string `yaml:"type_prefixes,omitempty"`
-	Combine                    bool     `yaml:"combine_defaults,omitempty"`
-	DescriptionContentLength   int      `yaml:"description_content_length,omitempty"`
+	TypePrefix                 []string                      `yaml:"type_prefixes,omitempty"`
+	Combine                    bool                          `yaml:"combine_defaults,omitempty"`
+	AllowedScopes              []string                      `yaml:"allowed_scopes,omitempty"`
+	MaxSubjectLength           int                           `yaml:"max_subject_length,omitempty"`
+	LowercaseSubject           bool                          `yaml:"lowercase_subject,omitempty"`
+	NoTrailingPeriod           bool                          `yaml:"no_trailing_period,omitempty"`
+	IssueReferences            IssueReferences               `yaml:"issue_references,omitempty"`
+	DescriptionContentLength   int                           `yaml:"description_content_length,omitempty"`
+	Checks                     map[string]CheckConfiguration `yaml:"checks,omitempty"`
+}
+
+// CheckConfiguration defines whether the check is enabled (true by default for all checks but commit-messages
+// and referenced-issues),
+// its severity (error by default) and the options specific for the check
+type CheckConfiguration struct {
+	Enabled  *bool             `yaml:"enabled,omitempty"`
+	Severity string            `yaml:"severity,omitempty"`
+	Options  map[string]string `yaml:"options,omitempty"`
+}
+
+func (c CheckConfiguration) enabled(byDefault bool) bool {
+	if c.Enabled == nil {
+		return byDefault
+	}
+	return *c.Enabled
+}
+
+func (c CheckConfiguration) severity(logger log.Logger, check string) string {
+	switch c.Severity {
+	case "", SeverityError:
+		return SeverityError
+	case SeverityWarning:
+		return SeverityWarning
+	default:
+		logger.Warnf("unknown severity %q of %q check, %q is used instead", c.Severity, check, SeverityError)
+		return SeverityError
+	}
+}
+
+// titleRules creates the rules the PR title is validated against
+func (c PluginConfiguration) titleRules() TitleRules {
+	return TitleRules{
+		Types:            GetValidTitlePrefixes(c),
+		Scopes:           c.AllowedScopes,
+		MaxSubjectLength: c.MaxSubjectLength,
+		LowercaseSubject: c.LowercaseSubject,
+		NoTrailingPeriod: c.NoTrailingPeriod,
+	}
+}
+
+// checkOption returns the value of the option configured for the check with the given name or an empty string when not set
+func (c PluginConfiguration) checkOption(check, option string) string {
+	return c.Checks[check].Options[option]
 }
 
 // LoadConfiguration loads a PluginConfiguration for the given change
@@ -36,5 +86,7 @@ func LoadConfiguration(logger log.Logger, change scm.RepositoryChange) PluginCon
 		return configuration
 	}
 
+	configuration.IssueReferences.compile(logger)
+
 	return configuration
 }
@@ -46,6 +46,57 @@ var _ = Describe("PR Sanitizer config loader features", func() {
 			Expect(configuration.DescriptionContentLength).To(Equal(40))
 		})
 
+		It("should load configuration of the checks", func() {
+			// given
+			change := scm.RepositoryChange{
+				Owner:    "owner",
+				RepoName: "repo",
+				Hash:     "46cb8fac44709e4ccaae97448c65e8f7320cfea7",
+			}
+			mocker.AddConfig(
+				ConfigYml(`checks:
+  issue-link:
+    enabled: false
+  description-length:
+    severity: warning
+    options:
+      min_length: 80`)).
+				ToChange(change)
+
+			// when
+			configuration := prsanitizer.LoadConfiguration(logger, change)
+
+			// then
+			Expect(*configuration.Checks[prsanitizer.IssueLinkCheck].Enabled).To(BeFalse())
+			Expect(configuration.Checks[prsanitizer.DescriptionLengthCheck].Severity).To(Equal(prsanitizer.SeverityWarning))
+			Expect(configuration.Checks[prsanitizer.DescriptionLengthCheck].Options).To(HaveKeyWithValue("min_length", "80"))
+		})
+
+		It("should load accepted issue references", func() {
+			// given
+			change := scm.RepositoryChange{
+				Owner:    "owner",
+				RepoName: "repo",
+				Hash:     "46cb8fac44709e4ccaae97448c65e8f7320cfea7",
+			}
+			mocker.AddConfig(
+				ConfigYml(`issue_references:
+  github_keywords: false
+  jira_projects: [PROJ, ABC]
+  patterns: ['BZ#[\d]+']
+  tracker_urls: [https://issues.example.com/browse]`)).
+				ToChange(change)
+
+			// when
+			configuration := prsanitizer.LoadConfiguration(logger, change)
+
+			// then
+			Expect(*configuration.IssueReferences.GitHubKeywords).To(BeFalse())
+			Expect(configuration.IssueReferences.JiraProjects).To(ConsistOf("PROJ", "ABC"))
+			Expect(configuration.IssueReferences.Patterns).To(ConsistOf(`BZ#[\d]+`))
+			Expect(configuration.IssueReferences.TrackerURLs).To(ConsistOf("https://issues.example.com/browse"))
+		})
+
 		It("should not load pr-sanitizer configuration yaml file and return empty url when config is not accessible", func() {
 			// given
 			NonExistingRawGitHubFiles("pr-sanitizer.yml", "pr-sanitizer.yaml")
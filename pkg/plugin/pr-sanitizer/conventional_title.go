@@ -0,0 +1,174 @@
+package prsanitizer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ConventionalTitle holds the parts of a PR title following the Conventional Commits style: type(scope)!: subject
+type ConventionalTitle struct {
+	Type     string
+	Scope    string
+	Breaking bool
+	Subject  string
+}
+
+// TitleRules defines the valid types and the optional list of allowed scopes (any scope is allowed when empty) together
+// with the rules the subject has to satisfy. MaxSubjectLength equal to zero means no limit
+type TitleRules struct {
+	Types            []string
+	Scopes           []string
+	MaxSubjectLength int
+	LowercaseSubject bool
+	NoTrailingPeriod bool
+}
+
+// TitleError describes the rule the title violates together with the part of the title (given by byte offsets)
+// which is wrong
+type TitleError struct {
+	Title      string
+	Message    string
+	Start, End int
+}
+
+func (e *TitleError) Error() string {
+	return e.Message
+}
+
+// Pointer returns the title and the line underlining the part which is wrong
+func (e *TitleError) Pointer() string {
+	offset := utf8.RuneCountInString(e.Title[:e.Start])
+	length := utf8.RuneCountInString(e.Title[e.Start:e.End])
+	if length == 0 {
+		length = 1
+	}
+	return e.Title + "\n" + strings.Repeat(" ", offset) + strings.Repeat("^", length)
+}
+
+// ParseTitle parses the given title following the Conventional Commits grammar type(scope)!: subject where the scope and
+// the breaking change marker are optional. Types are matched case-insensitively and a type ending with a colon
+// (e.g. an emoji code like :star:) can be followed directly by a space. Returns TitleError when the title doesn't
+// conform with the grammar or violates any of the given rules
+func ParseTitle(title string, rules TitleRules) (ConventionalTitle, error) {
+	title = strings.TrimSpace(title)
+	parsed := ConventionalTitle{}
+	fail := func(start, end int, format string, args ...interface{}) (ConventionalTitle, error) {
+		return parsed, &TitleError{Title: title, Message: fmt.Sprintf(format, args...), Start: start, End: end}
+	}
+
+	if title == "" {
+		return fail(0, 0, "the title is empty")
+	}
+	parsed.Type = matchType(title, rules.Types)
+	if parsed.Type == "" {
+		end := typeTokenEnd(title)
+		return fail(0, end, "the type `%s` is not any of the valid types", title[:end])
+	}
+
+	pos := len(parsed.Type)
+	if strings.HasPrefix(title[pos:], "(") {
+		closing := strings.Index(title[pos:], ")")
+		if closing < 0 {
+			return fail(pos, len(title), "the scope is not closed by `)`")
+		}
+		parsed.Scope = title[pos+1 : pos+closing]
+		switch {
+		case strings.TrimSpace(parsed.Scope) == "":
+			return fail(pos, pos+closing+1, "the scope is empty")
+		case len(rules.Scopes) > 0 && !containsIgnoringCase(rules.Scopes, parsed.Scope):
+			return fail(pos+1, pos+closing, "the scope `%s` is not any of the allowed scopes: %s", parsed.Scope, inlineCodeList(rules.Scopes))
+		}
+		pos += closing + 1
+	}
+
+	if strings.HasPrefix(title[pos:], "!") {
+		parsed.Breaking = true
+		pos++
+		if strings.HasPrefix(title[pos:], "(") {
+			return fail(pos-1, pos, "the breaking change marker `!` has to follow the scope")
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(title[pos:], ": "):
+		pos += 2
+	case strings.HasSuffix(parsed.Type, ":") && pos == len(parsed.Type) && strings.HasPrefix(title[pos:], " "):
+		pos++
+	case strings.HasPrefix(title[pos:], ":"):
+		return fail(pos, pos+1, "the colon has to be followed by a space")
+	default:
+		return fail(pos, pos+1, "the %s has to be followed by a colon and a space", lastPart(parsed))
+	}
+
+	parsed.Subject = strings.TrimSpace(title[pos:])
+	subjectStart := len(title) - len(parsed.Subject)
+	first, _ := utf8.DecodeRuneInString(parsed.Subject)
+	switch {
+	case parsed.Subject == "":
+		return fail(len(title), len(title), "the subject is empty")
+	case rules.MaxSubjectLength > 0 && utf8.RuneCountInString(parsed.Subject) > rules.MaxSubjectLength:
+		return fail(subjectStart, len(title), "the subject is %d characters long, but at most %d characters are allowed",
+			utf8.RuneCountInString(parsed.Subject), rules.MaxSubjectLength)
+	case rules.NoTrailingPeriod && strings.HasSuffix(parsed.Subject, "."):
+		return fail(len(title)-1, len(title), "the subject ends with a period")
+	case rules.LowercaseSubject && unicode.IsUpper(first):
+		return fail(subjectStart, subjectStart+utf8.RuneLen(first), "the subject has to start with a lowercase letter")
+	}
+	return parsed, nil
+}
+
+// matchType returns the longest of the given types the title starts with (ignoring case) which is followed by the scope,
+// the breaking change marker, the colon or a space. Returns an empty string if there is no such type
+func matchType(title string, types []string) string {
+	sorted := append([]string(nil), types...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+	for _, validType := range sorted {
+		if validType == "" || len(title) < len(validType) || !strings.EqualFold(title[:len(validType)], validType) {
+			continue
+		}
+		if rest := title[len(validType):]; rest == "" || strings.ContainsAny(rest[:1], "(!: ") {
+			return title[:len(validType)]
+		}
+	}
+	return ""
+}
+
+// typeTokenEnd returns the end of the part of the title which is supposed to be the type
+func typeTokenEnd(title string) int {
+	if end := strings.IndexAny(title, "(!: "); end > 0 {
+		return end
+	}
+	if end := strings.Index(title, " "); end > 0 {
+		return end
+	}
+	return len(title)
+}
+
+func lastPart(parsed ConventionalTitle) string {
+	switch {
+	case parsed.Breaking:
+		return "breaking change marker `!`"
+	case parsed.Scope != "":
+		return "scope"
+	default:
+		return "type"
+	}
+}
+
+func containsIgnoringCase(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func inlineCodeList(values []string) string {
+	return "`" + strings.Join(values, "`, `") + "`"
+}
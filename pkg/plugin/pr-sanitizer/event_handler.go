@@ -17,7 +17,7 @@ type GitHubPRSanitizerEventsHandler struct {
 }
 
 var (
-	handledCommentActions = []string{"created", "edited"}
+	handledCommentActions = []string{"created", "edited", "submitted"}
 	handledPrActions      = []string{"opened", "reopened", "edited", "synchronize"}
 )
 
@@ -29,49 +29,68 @@ func (gh *GitHubPRSanitizerEventsHandler) HandlePullRequestEvent(logger log.Logg
 	if !utils.Contains(handledPrActions, *event.Action) {
 		return nil
 	}
-	return gh.validatePullRequestTitleAndDescription(logger, event.PullRequest)
+	config := LoadConfiguration(logger, ghservice.NewRepositoryChangeForPR(event.PullRequest))
+	return gh.validatePullRequestTitleAndDescription(logger, event.PullRequest, config)
 }
 
 // HandleIssueCommentEvent is an entry point for the plugin logic. This method is invoked by the Server when
 // issue comment event is dispatched from the /hook service
 func (gh *GitHubPRSanitizerEventsHandler) HandleIssueCommentEvent(logger log.Logger, comment *gogh.IssueCommentEvent) error {
-	if !utils.Contains(handledCommentActions, *comment.Action) {
+	return gh.handleCommand(logger, command.NewIssueCommentSource(comment))
+}
+
+// HandlePullRequestReviewEvent is an entry point for the plugin logic. This method is invoked by the Server when
+// pull request review event is dispatched from the /hook service
+func (gh *GitHubPRSanitizerEventsHandler) HandlePullRequestReviewEvent(logger log.Logger, review *gogh.PullRequestReviewEvent) error {
+	return gh.handleCommand(logger, command.NewReviewSource(review))
+}
+
+// HandlePullRequestReviewCommentEvent is an entry point for the plugin logic. This method is invoked by the Server when
+// pull request review comment event is dispatched from the /hook service
+func (gh *GitHubPRSanitizerEventsHandler) HandlePullRequestReviewCommentEvent(logger log.Logger, comment *gogh.PullRequestReviewCommentEvent) error {
+	return gh.handleCommand(logger, command.NewReviewCommentSource(comment))
+}
+
+func (gh *GitHubPRSanitizerEventsHandler) handleCommand(logger log.Logger, source *command.CmdSource) error {
+	if !utils.Contains(handledCommentActions, source.Action) {
 		return nil
 	}
 
-	prLoader := ghservice.NewPullRequestLazyLoaderFromComment(gh.Client, comment)
-	userPerm := command.NewPermissionService(gh.Client, *comment.Sender.Login, prLoader)
+	prLoader := source.PullRequestLoader(gh.Client)
+	userPerm := command.NewPermissionService(gh.Client, source.Author, prLoader)
+	commandsConfig := command.NewConfigurationLoader(logger, ProwPluginName, prLoader)
 
-	cmdHandler := command.CommentCmdHandler{Client: gh.Client}
+	cmdHandler := command.CommentCmdHandler{Client: gh.Client, PluginName: ProwPluginName}
 	cmdHandler.Register(&command.RunCmd{
 		PluginName:            ProwPluginName,
 		UserPermissionService: userPerm,
+		Permissions:           commandsConfig.Permissions,
+		Feedback:              commandsConfig.Feedback,
 		WhenAddedOrEdited: func() error {
 			pullRequest, err := prLoader.Load()
 			if err != nil {
 				return err
 			}
 
-			return gh.validatePullRequestTitleAndDescription(logger, pullRequest)
+			return gh.validatePullRequestTitleAndDescription(logger, pullRequest, LoadConfiguration(logger, ghservice.NewRepositoryChangeForPR(pullRequest)))
 		}})
 
-	err := cmdHandler.Handle(logger, comment)
+	err := cmdHandler.Handle(logger, source)
 	if err != nil {
 		logger.Error(err)
 	}
 	return err
 }
 
-func (gh *GitHubPRSanitizerEventsHandler) validatePullRequestTitleAndDescription(logger log.Logger, pr *gogh.PullRequest) error {
-	change := ghservice.NewRepositoryChangeForPR(pr)
-	config := LoadConfiguration(logger, change)
+func (gh *GitHubPRSanitizerEventsHandler) validatePullRequestTitleAndDescription(logger log.Logger, pr *gogh.PullRequest,
+	config PluginConfiguration) error {
 	statusService := gh.newPrSanitizerStatusService(logger, pr, config)
 
-	messages := executeChecks(pr, config, logger)
+	errors, warnings := executeChecks(gh.Client, pr, config, logger)
 
-	if len(messages) > 0 {
-		return statusService.fail(messages)
+	if len(errors) > 0 {
+		return statusService.fail(errors, warnings)
 	}
 
-	return statusService.success()
+	return statusService.success(warnings)
 }
@@ -2,11 +2,14 @@ package prsanitizer_test
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/arquillian/ike-prow-plugins/pkg/command"
+	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
 	. "github.com/arquillian/ike-prow-plugins/pkg/internal/test"
 	"github.com/arquillian/ike-prow-plugins/pkg/log"
 	prsanitizer "github.com/arquillian/ike-prow-plugins/pkg/plugin/pr-sanitizer"
+	gogh "github.com/google/go-github/v41/github"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	gock "gopkg.in/h2non/gock.v1"
@@ -17,6 +20,13 @@ import (
 
 const botName = "alien-ike"
 
+func checkNoTodo(pr *gogh.PullRequest, config prsanitizer.PluginConfiguration, logger log.Logger) string {
+	if strings.Contains(pr.GetBody(), "TODO") {
+		return "#### No TODO\nThe PR description contains TODO."
+	}
+	return ""
+}
+
 var _ = Describe("PR Sanitizer Plugin features", func() {
 
 	var handler *prsanitizer.GitHubPRSanitizerEventsHandler
@@ -88,6 +98,32 @@ var _ = Describe("PR Sanitizer Plugin features", func() {
 			Ω(err).ShouldNot(HaveOccurred())
 		})
 
+		It("should mark status as failed and point at the scope when it is not any of the configured scopes", func() {
+			// given
+			title := "feat(ui): introduces dummy response"
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle(title).
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n fixes: #2").
+				WithConfigFile(ConfigYml(`allowed_scopes:
+  - api
+  - cli`)).
+				WithoutComments().
+				WithoutMessageFiles("pr-sanitizer_failed_message.md").
+				WithoutConfigFilesForPlugin(wip.ProwPluginName).
+				Expecting(
+					Status(ToBe(github.StatusFailure, prsanitizer.FailureMessage, prsanitizer.FailureDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains("the scope `ui` is not any of the allowed scopes: `api`, `cli`"),
+						HaveBodyThatContains(title+"\n     ^^\n")))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
 		It("should mark status as success (thus unblock PR merge) when title updated to contain semantic commit message type", func() {
 			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
@@ -180,6 +216,7 @@ var _ = Describe("PR Sanitizer Plugin features", func() {
 			// given
 			title := "PR from external user without tests should be rejected"
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
 				WithTitle(title).
 				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n fixes: #2").
 				WithoutComments().
@@ -273,4 +310,487 @@ var _ = Describe("PR Sanitizer Plugin features", func() {
 			Ω(err).ShouldNot(HaveOccurred())
 		})
 	})
+
+	Context("Configurable checks", func() {
+
+		BeforeEach(func() {
+			defer gock.OffAll()
+			handler = &prsanitizer.GitHubPRSanitizerEventsHandler{Client: NewDefaultGitHubClient(), BotName: botName}
+		})
+
+		AfterEach(func() {
+			prsanitizer.UnregisterCheck("no-todo")
+		})
+
+		AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+		It("should mark status as success when PR doesn't have issue linked and the check is disabled", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.").
+				WithConfigFile(ConfigYml(`checks:
+  issue-link:
+    enabled: false`)).
+				WithoutComments().
+				Expecting(Status(ToBe(github.StatusSuccess, prsanitizer.SuccessMessage, prsanitizer.SuccessDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should mark status as success and comment on short description when the check is only a warning", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("fix: introduces dummy response").
+				WithDescription("this pr fixes: #3").
+				WithConfigFile(ConfigYml(`checks:
+  description-length:
+    severity: warning`)).
+				WithoutComments().
+				WithoutMessageFiles("pr-sanitizer_warnings_message.md").
+				Expecting(
+					Status(ToBe(github.StatusSuccess, prsanitizer.SuccessMessage, prsanitizer.SuccessDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains(prsanitizer.WarningsStatusMessageBeginning),
+						HaveBodyThatContains(fmt.Sprintf(prsanitizer.DescriptionLengthShortMessage, 50, 7))))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should mark status as failed and list both errors and warnings", func() {
+			// given
+			title := "introduces dummy response"
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle(title).
+				WithDescription("This pr introduces dummy response which is adding new method.").
+				WithConfigFile(ConfigYml(`checks:
+  issue-link:
+    severity: warning`)).
+				WithoutComments().
+				WithoutMessageFiles("pr-sanitizer_failed_message.md").
+				WithoutConfigFilesForPlugin(wip.ProwPluginName).
+				Expecting(
+					Status(ToBe(github.StatusFailure, prsanitizer.FailureMessage, prsanitizer.FailureDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains(title),
+						HaveBodyThatContains(prsanitizer.WarningsStatusMessageBeginning+prsanitizer.IssueLinkMissingMessage)))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should mark status as failed when PR description is shorter than the length set by the check option", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n fixes: #3").
+				WithConfigFile(ConfigYml(`checks:
+  description-length:
+    options:
+      min_length: 80`)).
+				WithoutComments().
+				WithoutMessageFiles("pr-sanitizer_failed_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, prsanitizer.FailureMessage, prsanitizer.FailureDetailsPageName)),
+					Comment(ContainingStatusMessage(fmt.Sprintf(prsanitizer.DescriptionLengthShortMessage, 80, 61)))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should execute registered custom check", func() {
+			// given
+			prsanitizer.RegisterCheck("no-todo", checkNoTodo)
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n fixes: #3 TODO").
+				WithoutConfigFiles().
+				WithoutComments().
+				WithoutMessageFiles("pr-sanitizer_failed_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, prsanitizer.FailureMessage, prsanitizer.FailureDetailsPageName)),
+					Comment(ContainingStatusMessage("The PR description contains TODO."))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(prsanitizer.RegisteredChecks()).To(ContainElement("no-todo"))
+		})
+	})
+
+	Context("Issue references", func() {
+
+		BeforeEach(func() {
+			defer gock.OffAll()
+			handler = &prsanitizer.GitHubPRSanitizerEventsHandler{Client: NewDefaultGitHubClient(), BotName: botName}
+		})
+
+		AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+		It("should mark status as success when description references Jira issue of configured project", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n Implements PROJ-1234").
+				WithConfigFile(ConfigYml(`issue_references:
+  jira_projects: [PROJ]`)).
+				WithoutComments().
+				Expecting(Status(ToBe(github.StatusSuccess, prsanitizer.SuccessMessage, prsanitizer.SuccessDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should mark status as failed and list accepted references when description doesn't reference any issue", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n Implements XYZ-1234").
+				WithConfigFile(ConfigYml(`issue_references:
+  jira_projects: [PROJ]`)).
+				WithoutComments().
+				WithoutMessageFiles("pr-sanitizer_failed_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, prsanitizer.FailureMessage, prsanitizer.FailureDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains("The PR description is missing any reference to the issue the PR is related to."),
+						HaveBodyThatContains("* key of a Jira issue of any of the projects: `PROJ` (e.g. `PROJ-1234`)")))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("Commit messages check", func() {
+
+		BeforeEach(func() {
+			defer gock.OffAll()
+			handler = &prsanitizer.GitHubPRSanitizerEventsHandler{Client: NewDefaultGitHubClient(), BotName: botName}
+		})
+
+		AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+		It("should mark status as success when all commit messages conform with semantic commit message style", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n fixes: #2").
+				WithConfigFile(ConfigYml(`checks:
+  commit-messages:
+    enabled: true`)).
+				WithCommits(semanticCommits).
+				WithoutComments().
+				Expecting(Status(ToBe(github.StatusSuccess, prsanitizer.SuccessMessage, prsanitizer.SuccessDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should mark status as failed and list SHAs of the commits with messages not conforming with semantic commit message style", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n fixes: #2").
+				WithConfigFile(ConfigYml(`max_subject_length: 30
+checks:
+  commit-messages:
+    enabled: true`)).
+				WithCommits(nonSemanticCommits).
+				WithoutComments().
+				WithoutMessageFiles("pr-sanitizer_failed_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, prsanitizer.FailureMessage, prsanitizer.FailureDetailsPageName)),
+					Comment(ContainingStatusMessage(fmt.Sprintf(prsanitizer.CommitMessagesFailureMessage,
+						"* 3c7e1f2 `introduce dummy response` - the type `introduce` is not any of the valid types\n"+
+							"* 9b0d4a8 `fix: handle the response in all supported and unsupported formats` - "+
+							"the subject is 60 characters long, but at most 30 characters are allowed")))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should mark status as failed when there are fixup and squash commits and the check is configured to reject them", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n fixes: #2").
+				WithConfigFile(ConfigYml(`checks:
+  commit-messages:
+    enabled: true
+    options:
+      reject_autosquash: true`)).
+				WithCommits(autosquashCommits).
+				WithoutComments().
+				WithoutMessageFiles("pr-sanitizer_failed_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, prsanitizer.FailureMessage, prsanitizer.FailureDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains("* 8f3a2c4 `fixup! feat: introduce dummy response` - the commit is marked by `fixup!` to be squashed"),
+						HaveBodyThatContains("* 1d6e9b0 `squash! feat: introduce dummy response` - the commit is marked by `squash!` to be squashed")))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should mark status as failed when the commits can't be listed", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n fixes: #2").
+				WithConfigFile(ConfigYml(`checks:
+  commit-messages:
+    enabled: true`)).
+				WithoutComments().
+				WithoutMessageFiles("pr-sanitizer_failed_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, prsanitizer.FailureMessage, prsanitizer.FailureDetailsPageName)),
+					Comment(ContainingStatusMessage(prsanitizer.CommitMessagesUnverifiedMessage))).
+				Create()
+			gock.New("https://api.github.com").
+				Get("/repos/bartoszmajsak/wfswarm-booster-pipeline-test/pulls/1/commits").
+				Reply(500)
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should mark status as failed when the pull request contains more commits than GitHub lists", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n fixes: #2").
+				WithConfigFile(ConfigYml(`checks:
+  commit-messages:
+    enabled: true`)).
+				WithCommitsCount(300).
+				WithCommits(generatedCommits(ghclient.PullRequestCommitsLimit)).
+				WithoutComments().
+				WithoutMessageFiles("pr-sanitizer_failed_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, prsanitizer.FailureMessage, prsanitizer.FailureDetailsPageName)),
+					Comment(ContainingStatusMessage("#### Commit messages\n"+
+						fmt.Sprintf(prsanitizer.CommitMessagesTruncatedMessage, "300", ghclient.PullRequestCommitsLimit)))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should ignore fixup and squash commits when the check is not configured to reject them", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n fixes: #2").
+				WithConfigFile(ConfigYml(`checks:
+  commit-messages:
+    enabled: true`)).
+				WithCommits(autosquashCommits).
+				WithoutComments().
+				Expecting(Status(ToBe(github.StatusSuccess, prsanitizer.SuccessMessage, prsanitizer.SuccessDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("Referenced issues check", func() {
+
+		BeforeEach(func() {
+			defer gock.OffAll()
+			handler = &prsanitizer.GitHubPRSanitizerEventsHandler{Client: NewDefaultGitHubClient(), BotName: botName}
+		})
+
+		AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+		It("should mark status as success when any of the referenced issues is an existing open issue", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n fixes: #2, resolves #3").
+				WithConfigFile(ConfigYml(referencedIssuesConfig)).
+				WithIssue("", 2, `{"number":2,"title":"Dummy response is returned","state":"closed"}`).
+				WithIssue("", 3, `{"number":3,"title":"Introduce dummy response","state":"open"}`).
+				WithoutComments().
+				Expecting(Status(ToBe(github.StatusSuccess, prsanitizer.SuccessMessage, prsanitizer.SuccessDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should mark status as success when the referenced issue from other repository is open", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n closes arquillian/ike-prow-plugins#42").
+				WithConfigFile(ConfigYml(referencedIssuesConfig)).
+				WithIssue("arquillian/ike-prow-plugins", 42, `{"number":42,"title":"Introduce dummy response","state":"open"}`).
+				WithoutComments().
+				Expecting(Status(ToBe(github.StatusSuccess, prsanitizer.SuccessMessage, prsanitizer.SuccessDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should mark status as failed and show resolved issues when none of them is an existing open issue", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n"+
+					"fixes: #2, resolves #4, closes arquillian/ike-prow-plugins#404, fixes #2").
+				WithConfigFile(ConfigYml(referencedIssuesConfig)).
+				WithIssue("", 2, `{"number":2,"title":"Dummy response is returned","state":"closed"}`).
+				WithIssue("", 4, "{\"number\":4,\"title\":\"feat: introduces **response** for @alien-ike `ping`\",\"state\":\"open\","+
+					`"pull_request":{"url":"https://api.github.com/repos/bartoszmajsak/wfswarm-booster-pipeline-test/pulls/4"}}`).
+				WithoutIssue("arquillian/ike-prow-plugins", 404).
+				WithoutComments().
+				WithoutMessageFiles("pr-sanitizer_failed_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, prsanitizer.FailureMessage, prsanitizer.FailureDetailsPageName)),
+					Comment(ContainingStatusMessage(fmt.Sprintf(prsanitizer.ReferencedIssuesFailureMessage,
+						"* `bartoszmajsak/wfswarm-booster-pipeline-test#2` `Dummy response is returned` - the issue is closed\n"+
+							"* `bartoszmajsak/wfswarm-booster-pipeline-test#4` `feat: introduces **response** for @alien-ike 'ping'` - it's a pull request\n"+
+							"* `arquillian/ike-prow-plugins#404` - the issue doesn't exist")))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should mark status as success when some of the referenced issues couldn't be resolved", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n"+
+					"fixes: #2, fixes arquillian/ike-prow-plugins#500").
+				WithConfigFile(ConfigYml(referencedIssuesConfig)).
+				WithIssue("", 2, `{"number":2,"title":"Dummy response is returned","state":"closed"}`).
+				WithoutComments().
+				Expecting(Status(ToBe(github.StatusSuccess, prsanitizer.SuccessMessage, prsanitizer.SuccessDetailsPageName))).
+				Create()
+			gock.New("https://api.github.com").
+				Get("/repos/arquillian/ike-prow-plugins/issues/500$").
+				Reply(500)
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should not resolve the referenced issues when GitHub keywords are not accepted as issue references", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithTitle("feat: introduces dummy response").
+				WithDescription("This pr introduces dummy response which is adding new method.\r\n\r\n" +
+					"fixes #2, relates to IKE-42").
+				WithConfigFile(ConfigYml(referencedIssuesConfig + `
+issue_references:
+  github_keywords: false
+  jira_projects:
+    - IKE`)).
+				WithoutComments().
+				Expecting(Status(ToBe(github.StatusSuccess, prsanitizer.SuccessMessage, prsanitizer.SuccessDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
 })
+
+const referencedIssuesConfig = `checks:
+  referenced-issues:
+    enabled: true`
+
+const semanticCommits = `[
+	{"sha":"5e2f9c1a0b8d7e6f5a4b3c2d1e0f9a8b7c6d5e4f", "commit":{"message":"feat: introduce dummy response\n\nThe response is returned for all requests."}, "parents":[{"sha":"0a1b2c3"}]},
+	{"sha":"9b0d4a8f1e2c3b4a5d6e7f8a9b0c1d2e3f4a5b6c", "commit":{"message":"fix(api): handle empty request"}, "parents":[{"sha":"5e2f9c1"}]}
+]`
+
+const nonSemanticCommits = `[
+	{"sha":"5e2f9c1a0b8d7e6f5a4b3c2d1e0f9a8b7c6d5e4f", "commit":{"message":"feat: introduce dummy response"}, "parents":[{"sha":"0a1b2c3"}]},
+	{"sha":"3c7e1f2d4b5a6c7d8e9f0a1b2c3d4e5f6a7b8c9d", "commit":{"message":"introduce dummy response\n\nfeat: it's not the subject"}, "parents":[{"sha":"5e2f9c1"}]},
+	{"sha":"d41a7b3e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c", "commit":{"message":"Merge branch 'master' into dummy-response"}, "parents":[{"sha":"3c7e1f2"}, {"sha":"0f1e2d3"}]},
+	{"sha":"9b0d4a8f1e2c3b4a5d6e7f8a9b0c1d2e3f4a5b6c", "commit":{"message":"fix: handle the response in all supported and unsupported formats"}, "parents":[{"sha":"d41a7b3"}]}
+]`
+
+const autosquashCommits = `[
+	{"sha":"5e2f9c1a0b8d7e6f5a4b3c2d1e0f9a8b7c6d5e4f", "commit":{"message":"feat: introduce dummy response"}, "parents":[{"sha":"0a1b2c3"}]},
+	{"sha":"8f3a2c4b5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a", "commit":{"message":"fixup! feat: introduce dummy response"}, "parents":[{"sha":"5e2f9c1"}]},
+	{"sha":"1d6e9b0c2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d", "commit":{"message":"squash! feat: introduce dummy response"}, "parents":[{"sha":"8f3a2c4"}]}
+]`
+
+// generatedCommits creates a payload of the given number of commits with semantic messages
+func generatedCommits(count int) string {
+	commits := make([]string, count)
+	for i := range commits {
+		commits[i] = fmt.Sprintf(`{"sha":"%040d", "commit":{"message":"feat: introduce response %d"}, "parents":[{"sha":"0a1b2c3"}]}`, i, i)
+	}
+	return "[" + strings.Join(commits, ",") + "]"
+}
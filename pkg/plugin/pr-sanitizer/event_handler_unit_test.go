@@ -1,6 +1,8 @@
 package prsanitizer_test
 
 import (
+	"fmt"
+
 	"github.com/arquillian/ike-prow-plugins/pkg/log"
 	prsanitizer "github.com/arquillian/ike-prow-plugins/pkg/plugin/pr-sanitizer"
 	"github.com/arquillian/ike-prow-plugins/pkg/utils"
@@ -25,6 +27,7 @@ var _ = Describe("PR-Sanitizer Plugin features", func() {
 			Entry("refactor prefix", "refactor(#1): share logic between 4d3d3d3 and flarhgunnstow"),
 			Entry("style prefix", "style(#1): convert tabs to spaces"),
 			Entry("tests prefix", "test(#1): ensure Tayne retains clothing"),
+			Entry("subject with trailing period", "feat: add beta sequence."),
 		)
 
 		DescribeTable("should not recognize PR as compliant with semantic commit message if title doesn't start with any default prefix",
@@ -36,7 +39,99 @@ var _ = Describe("PR-Sanitizer Plugin features", func() {
 			Entry("title starting by fixes", "fixes failing test"),
 			Entry("empty title", ""),
 			Entry("nil title", nil),
+			Entry("type without colon", "feat add beta sequence"),
+			Entry("colon not followed by space", "feat:add beta sequence"),
+			Entry("empty subject", "feat: "),
+		)
+
+		DescribeTable("should parse all parts of the title",
+			func(title string, expected prsanitizer.ConventionalTitle) {
+				// when
+				parsed, err := prsanitizer.ParseTitle(title, prsanitizer.TitleRules{Types: []string{"feat", "fix", ":star:"}})
+
+				// then
+				Ω(err).ShouldNot(HaveOccurred())
+				Expect(parsed).To(Equal(expected))
+			},
+			Entry("type and subject", "feat: add beta sequence",
+				prsanitizer.ConventionalTitle{Type: "feat", Subject: "add beta sequence"}),
+			Entry("type with scope", "fix(parser): remove broken confirmation message",
+				prsanitizer.ConventionalTitle{Type: "fix", Scope: "parser", Subject: "remove broken confirmation message"}),
+			Entry("breaking change marker", "feat!: drop support of 4d3d3d3",
+				prsanitizer.ConventionalTitle{Type: "feat", Breaking: true, Subject: "drop support of 4d3d3d3"}),
+			Entry("scope followed by breaking change marker", "feat(api)!: drop support of 4d3d3d3",
+				prsanitizer.ConventionalTitle{Type: "feat", Scope: "api", Breaking: true, Subject: "drop support of 4d3d3d3"}),
+			Entry("type in upper case", "FIX: remove broken confirmation message",
+				prsanitizer.ConventionalTitle{Type: "FIX", Subject: "remove broken confirmation message"}),
+			Entry("emoji type followed by space", ":star: configures plugin",
+				prsanitizer.ConventionalTitle{Type: ":star:", Subject: "configures plugin"}),
+		)
+
+		DescribeTable("should point at the part of the title which is wrong",
+			func(title string, rules prsanitizer.TitleRules, message, pointer string) {
+				// given
+				rules.Types = []string{"feat", "fix"}
+
+				// when
+				_, err := prsanitizer.ParseTitle(title, rules)
+
+				// then
+				Ω(err).Should(MatchError(message))
+				titleErr, ok := err.(*prsanitizer.TitleError)
+				Expect(ok).To(BeTrue())
+				Expect(titleErr.Pointer()).To(Equal(title + "\n" + pointer))
+			},
+			Entry("unknown type", "feature: add beta sequence", prsanitizer.TitleRules{},
+				"the type `feature` is not any of the valid types",
+				"^^^^^^^"),
+			Entry("unclosed scope", "feat(api: add beta sequence", prsanitizer.TitleRules{},
+				"the scope is not closed by `)`",
+				"    ^^^^^^^^^^^^^^^^^^^^^^^"),
+			Entry("empty scope", "feat(): add beta sequence", prsanitizer.TitleRules{},
+				"the scope is empty",
+				"    ^^"),
+			Entry("scope which is not allowed", "feat(ui): add beta sequence", prsanitizer.TitleRules{Scopes: []string{"api", "cli"}},
+				"the scope `ui` is not any of the allowed scopes: `api`, `cli`",
+				"     ^^"),
+			Entry("breaking change marker before scope", "feat!(api): add beta sequence", prsanitizer.TitleRules{},
+				"the breaking change marker `!` has to follow the scope",
+				"    ^"),
+			Entry("missing colon after scope", "fix(api) remove broken confirmation message", prsanitizer.TitleRules{},
+				"the scope has to be followed by a colon and a space",
+				"        ^"),
+			Entry("missing space after colon", "fix:remove broken confirmation message", prsanitizer.TitleRules{},
+				"the colon has to be followed by a space",
+				"   ^"),
+			Entry("colon at the end", "fix(api)!:", prsanitizer.TitleRules{},
+				"the colon has to be followed by a space",
+				"         ^"),
+			Entry("too long subject", "feat: add beta sequence", prsanitizer.TitleRules{MaxSubjectLength: 10},
+				"the subject is 17 characters long, but at most 10 characters are allowed",
+				"      ^^^^^^^^^^^^^^^^^"),
+			Entry("trailing period", "feat: add beta sequence.", prsanitizer.TitleRules{NoTrailingPeriod: true},
+				"the subject ends with a period",
+				"                       ^"),
+			Entry("subject starting with upper case letter", "feat: Add beta sequence", prsanitizer.TitleRules{LowercaseSubject: true},
+				"the subject has to start with a lowercase letter",
+				"      ^"),
 		)
+
+		It("should accept allowed scope, trailing period and upper case subject unless forbidden", func() {
+			// given
+			rules := prsanitizer.TitleRules{
+				Types:            []string{"feat"},
+				Scopes:           []string{"api", "cli"},
+				MaxSubjectLength: 20,
+			}
+
+			// when
+			parsed, err := prsanitizer.ParseTitle("feat(CLI): Add Tayne.", rules)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(parsed.Scope).To(Equal("CLI"))
+			Expect(parsed.Subject).To(Equal("Add Tayne."))
+		})
 	})
 
 	Context("Description verifier", func() {
@@ -95,4 +190,126 @@ var _ = Describe("PR-Sanitizer Plugin features", func() {
 		)
 	})
 
+	Context("Issue references verifier", func() {
+
+		references := prsanitizer.IssueReferences{
+			JiraProjects: []string{"PROJ", "ABC"},
+			Patterns:     []string{`BZ#[\d]+`},
+			TrackerURLs:  []string{"https://issues.example.com/browse/"},
+		}
+
+		DescribeTable("should recognize configured issue references",
+			func(desc string) {
+				pr := &github.PullRequest{Body: utils.String(desc)}
+				config := prsanitizer.PluginConfiguration{IssueReferences: references}
+				msg := prsanitizer.CheckIssueLinkPresence(pr, config, log.NewTestLogger())
+				Expect(msg).To(BeEmpty())
+			},
+			Entry("GitHub issue link with keyword", "PR fixes #1"),
+			Entry("Jira key of allowed project", "PR implements PROJ-1234"),
+			Entry("Jira key of other allowed project at the beginning", "ABC-1 implemented"),
+			Entry("text matching custom pattern", "see BZ#1654321"),
+			Entry("URL of issue tracker", "see https://issues.example.com/browse/XYZ-42 for details"),
+		)
+
+		DescribeTable("should NOT recognize references which are not configured",
+			func(desc string) {
+				pr := &github.PullRequest{Body: utils.String(desc)}
+				config := prsanitizer.PluginConfiguration{IssueReferences: references}
+				msg := prsanitizer.CheckIssueLinkPresence(pr, config, log.NewTestLogger())
+				Expect(msg).To(HavePrefix("#### Issue link\nThe PR description is missing any reference to the issue"))
+				Expect(msg).To(ContainSubstring("`PROJ`, `ABC`"))
+			},
+			Entry("Jira key of project which is not allowed", "PR implements XYZ-1234"),
+			Entry("Jira key being part of a word", "PR implements MYPROJ-1234"),
+			Entry("lower case Jira key", "PR implements proj-1234"),
+			Entry("URL of other issue tracker", "see https://jira.example.com/browse/PROJECT-42"),
+			Entry("URL of the issue tracker host only", "see https://issues.example.com/browse/"),
+		)
+
+		It("should not accept GitHub issue links when disabled", func() {
+			// given
+			disabled := false
+			config := prsanitizer.PluginConfiguration{IssueReferences: prsanitizer.IssueReferences{
+				GitHubKeywords: &disabled,
+				JiraProjects:   []string{"PROJ"},
+			}}
+			pr := &github.PullRequest{Body: utils.String("PR fixes #1")}
+
+			// when
+			msg := prsanitizer.CheckIssueLinkPresence(pr, config, log.NewTestLogger())
+
+			// then
+			Expect(msg).To(Equal(fmt.Sprintf(prsanitizer.IssueReferenceMissingMessage,
+				"* key of a Jira issue of any of the projects: `PROJ` (e.g. `PROJ-1234`)")))
+		})
+
+		DescribeTable("should NOT accept identifiers resembling Jira keys when any project is configured",
+			func(desc string) {
+				// given
+				disabled := false
+				config := prsanitizer.PluginConfiguration{IssueReferences: prsanitizer.IssueReferences{
+					GitHubKeywords: &disabled,
+					JiraProjects:   []string{"*", "PROJ"},
+				}}
+				pr := &github.PullRequest{Body: utils.String(desc)}
+
+				// when
+				msg := prsanitizer.CheckIssueLinkPresence(pr, config, log.NewTestLogger())
+
+				// then
+				Expect(msg).To(Equal(fmt.Sprintf(prsanitizer.IssueReferenceMissingMessage,
+					"* key of a Jira issue of any of the projects: `PROJ` (e.g. `PROJ-1234`)")))
+			},
+			Entry("encoding", "Converts the files to UTF-8"),
+			Entry("hash algorithm", "Uses SHA-256 for checksums"),
+			Entry("standard", "Supports ISO-8859 charsets"),
+		)
+
+		DescribeTable("should ignore patterns matching any description",
+			func(pattern string) {
+				// given
+				config := prsanitizer.PluginConfiguration{DescriptionContentLength: 15, IssueReferences: prsanitizer.IssueReferences{
+					Patterns: []string{pattern},
+				}}
+				pr := &github.PullRequest{Body: utils.String("Short text")}
+
+				// when
+				issueMsg := prsanitizer.CheckIssueLinkPresence(pr, config, log.NewTestLogger())
+				lengthMsg := prsanitizer.CheckDescriptionLength(pr, config, log.NewTestLogger())
+
+				// then
+				Expect(issueMsg).To(HavePrefix("#### Issue link\nThe PR description is missing any reference to the issue"))
+				Expect(lengthMsg).To(Equal(fmt.Sprintf(prsanitizer.DescriptionLengthShortMessage, 15, 10)))
+			},
+			Entry("empty pattern", ""),
+			Entry("catch-all pattern", ".*"),
+			Entry("optional pattern", "(BZ#\\d+)?"),
+		)
+
+		It("should exclude configured issue references when the description length is being measured", func() {
+			// given
+			config := prsanitizer.PluginConfiguration{DescriptionContentLength: 15, IssueReferences: references}
+			pr := &github.PullRequest{Body: utils.String("Resolves PROJ-1234 ABC-1 BZ#1654321 https://issues.example.com/browse/XYZ-42")}
+
+			// when
+			msg := prsanitizer.CheckDescriptionLength(pr, config, log.NewTestLogger())
+
+			// then
+			Expect(msg).To(Equal(fmt.Sprintf(prsanitizer.DescriptionLengthShortMessage, 15, 8)))
+		})
+
+		It("should ignore invalid pattern", func() {
+			// given
+			config := prsanitizer.PluginConfiguration{IssueReferences: prsanitizer.IssueReferences{Patterns: []string{`BZ#(`}}}
+			pr := &github.PullRequest{Body: utils.String("PR fixes #1")}
+
+			// when
+			msg := prsanitizer.CheckIssueLinkPresence(pr, config, log.NewTestLogger())
+
+			// then
+			Expect(msg).To(BeEmpty())
+		})
+	})
+
 })
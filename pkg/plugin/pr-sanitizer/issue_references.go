@@ -0,0 +1,118 @@
+package prsanitizer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+)
+
+// anyJiraProject used to accept issue keys of any Jira project. It's not supported anymore as such keys can't be told
+// apart from other common identifiers (e.g. UTF-8, SHA-256 or ISO-8859), so it's ignored
+const anyJiraProject = "*"
+
+// IssueReferences defines which references to issues are accepted in the PR description. Apart from the issue links
+// used with the GitHub keywords (accepted by default), it can accept keys of issues of the listed Jira projects,
+// text matching any of the regular expressions and URLs pointing to any of the issue trackers
+type IssueReferences struct {
+	GitHubKeywords *bool    `yaml:"github_keywords,omitempty"`
+	JiraProjects   []string `yaml:"jira_projects,omitempty"`
+	Patterns       []string `yaml:"patterns,omitempty"`
+	TrackerURLs    []string `yaml:"tracker_urls,omitempty"`
+	compiled       []*regexp.Regexp
+	isCompiled     bool
+}
+
+func (r IssueReferences) gitHubKeywords() bool {
+	return r.GitHubKeywords == nil || *r.GitHubKeywords
+}
+
+func (r IssueReferences) external() bool {
+	return len(r.jiraProjects()) > 0 || len(r.Patterns) > 0 || len(r.TrackerURLs) > 0
+}
+
+// jiraProjects returns the configured Jira projects without the unsupported wildcard
+func (r IssueReferences) jiraProjects() []string {
+	projects := make([]string, 0, len(r.JiraProjects))
+	for _, project := range r.JiraProjects {
+		if project != anyJiraProject {
+			projects = append(projects, project)
+		}
+	}
+	return projects
+}
+
+// compile compiles the matchers of the accepted issue references once the configuration is loaded, so they are not
+// compiled (and the invalid ones reported) for each of the checks using them
+func (r *IssueReferences) compile(logger log.Logger) {
+	r.compiled = r.matchers(logger)
+	r.isCompiled = true
+}
+
+// matchers returns the regular expressions matching the accepted issue references. The patterns which are not valid
+// regular expressions or which match an empty text (and thus any description) are skipped
+func (r IssueReferences) matchers(logger log.Logger) []*regexp.Regexp {
+	if r.isCompiled {
+		return r.compiled
+	}
+	var matchers []*regexp.Regexp
+	if r.gitHubKeywords() {
+		matchers = append(matchers, issueLinkRegexp)
+	}
+	if containsIgnoringCase(r.JiraProjects, anyJiraProject) {
+		logger.Warnf("%q in Jira projects of issue references is not supported and is ignored - list the project keys instead", anyJiraProject)
+	}
+	if projects := r.jiraProjects(); len(projects) > 0 {
+		matchers = append(matchers, jiraKeyRegexp(projects))
+	}
+	for _, pattern := range r.Patterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			logger.Warnf("invalid issue reference pattern %q is ignored. cause: %s", pattern, err)
+			continue
+		}
+		if compiled.MatchString("") {
+			logger.Warnf("issue reference pattern %q matching an empty text is ignored", pattern)
+			continue
+		}
+		matchers = append(matchers, compiled)
+	}
+	for _, url := range r.TrackerURLs {
+		matchers = append(matchers, trackerURLRegexp(url))
+	}
+	return matchers
+}
+
+// description returns the list of the accepted references formatted in markdown
+func (r IssueReferences) description() string {
+	var accepted []string
+	if r.gitHubKeywords() {
+		accepted = append(accepted, "* issue link used with any of the "+
+			"[GitHub keywords](https://help.github.com/articles/closing-issues-using-keywords/) (e.g. `fixes #1`)")
+	}
+	if projects := r.jiraProjects(); len(projects) > 0 {
+		accepted = append(accepted, fmt.Sprintf("* key of a Jira issue of any of the projects: %s (e.g. `%s-1234`)",
+			inlineCodeList(projects), projects[0]))
+	}
+	if len(r.Patterns) > 0 {
+		accepted = append(accepted, "* text matching any of the regular expressions: "+inlineCodeList(r.Patterns))
+	}
+	if len(r.TrackerURLs) > 0 {
+		accepted = append(accepted, "* URL of an issue in any of the issue trackers: "+inlineCodeList(r.TrackerURLs))
+	}
+	return strings.Join(accepted, "\n")
+}
+
+func jiraKeyRegexp(projects []string) *regexp.Regexp {
+	quoted := make([]string, 0, len(projects))
+	for _, project := range projects {
+		quoted = append(quoted, regexp.QuoteMeta(project))
+	}
+	return regexp.MustCompile(`\b(` + strings.Join(quoted, "|") + `)-[\d]+\b`)
+}
+
+func trackerURLRegexp(url string) *regexp.Regexp {
+	url = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://"), "/")
+	return regexp.MustCompile(`https?://` + regexp.QuoteMeta(url) + `/[^\s)\]>]+`)
+}
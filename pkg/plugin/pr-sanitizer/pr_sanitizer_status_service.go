@@ -40,6 +40,13 @@ const (
 
 	// SuccessStatusMessage is a status message used when the PR is good
 	SuccessStatusMessage = "This pull request complies with the PR conventions given by the `pr-sanitizer` plugin. :)"
+
+	// WarningsStatusMessageBeginning is a beginning of the part of the status message listing the violations of the checks
+	// with warning severity
+	WarningsStatusMessageBeginning = "The following items are not required, but it's recommended to fix them:\n\n"
+
+	// SuccessWithWarningsStatusMessage is a status message used when the PR is good, but some of the checks with warning severity are not satisfied
+	SuccessWithWarningsStatusMessage = "This pull request complies with the PR conventions given by the `pr-sanitizer` plugin."
 )
 
 func (gh *GitHubPRSanitizerEventsHandler) newPrSanitizerStatusService(logger log.Logger, pr *gogh.PullRequest, config PluginConfiguration) prSanitizerStatusService {
@@ -59,13 +66,25 @@ func (gh *GitHubPRSanitizerEventsHandler) newPrSanitizerStatusService(logger log
 	}
 }
 
-func (ss *prSanitizerStatusService) success() error {
-	ss.statusMsgService.HappyStatusMessage(SuccessStatusMessage, "success", false)
+func (ss *prSanitizerStatusService) success(warnings []string) error {
+	if len(warnings) > 0 {
+		msg := SuccessWithWarningsStatusMessage + "\n\n" + warningsReport(warnings)
+		ss.statusMsgService.HappyStatusMessage(msg, "warnings", true)
+	} else {
+		ss.statusMsgService.HappyStatusMessage(SuccessStatusMessage, "success", false)
+	}
 	return ss.statusService.Success(SuccessMessage, SuccessDetailsPageName)
 }
 
-func (ss *prSanitizerStatusService) fail(messages []string) error {
-	msg := FailureStatusMessageBeginning + strings.Join(messages, "\n\n")
+func (ss *prSanitizerStatusService) fail(errors, warnings []string) error {
+	msg := FailureStatusMessageBeginning + strings.Join(errors, "\n\n")
+	if len(warnings) > 0 {
+		msg += "\n\n" + warningsReport(warnings)
+	}
 	ss.statusMsgService.SadStatusMessage(msg, "failed", true)
 	return ss.statusService.Failure(FailureMessage, FailureDetailsPageName)
 }
+
+func warningsReport(warnings []string) string {
+	return WarningsStatusMessageBeginning + strings.Join(warnings, "\n\n")
+}
@@ -0,0 +1,109 @@
+package prsanitizer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	gogh "github.com/google/go-github/v41/github"
+)
+
+// ReferencedIssuesFailureMessage is a status message that is used when none of the issues referenced in the PR description
+// is an existing open issue
+const ReferencedIssuesFailureMessage = "#### Referenced issues\nNone of the issues referenced in the PR description " +
+	"using the [GitHub keywords](https://help.github.com/articles/closing-issues-using-keywords/) is an existing open issue:\n\n%s\n\n" +
+	"Please, link the open issue the PR is related to."
+
+var issueReferenceRegexp = regexp.MustCompile(`(?i)(?:close|closes|closed|fix|fixes|fixed|resolve|resolves|resolved)[\s]*[:]?[\s]*(?:([\w.-]+)/([\w.-]+))?#([\d]+)`)
+
+type issueReference struct {
+	owner, repo string
+	number      int
+}
+
+func (r issueReference) String() string {
+	return fmt.Sprintf("%s/%s#%d", r.owner, r.repo, r.number)
+}
+
+// maxResolvedReferences limits the number of the distinct references resolved for a single PR, so a description with
+// a long list of references doesn't cause the same number of API calls
+const maxResolvedReferences = 10
+
+// CheckReferencedIssues resolves the issues referenced in the PR description (using the same keywords as the issue-link
+// check does) in the repository of the PR or in the repository given in the owner/repo#number format. The check passes
+// if any of them is an existing open issue (not a pull request). When there is no reference at all (or the GitHub keywords
+// are not accepted as issue references), then the check passes as well - the missing link is reported by issue-link check.
+// As the failure of GitHub API is not a fault of the PR, the check fails only when all the references have been resolved.
+// Only the first maxResolvedReferences references are resolved
+func CheckReferencedIssues(client ghclient.Client, pr *gogh.PullRequest, config PluginConfiguration, logger log.Logger) string {
+	if !config.IssueReferences.gitHubKeywords() {
+		return ""
+	}
+	references := findIssueReferences(pr)
+	if len(references) == 0 {
+		return ""
+	}
+	if len(references) > maxResolvedReferences {
+		logger.Warnf("the PR references %d issues, only the first %d of them are resolved", len(references), maxResolvedReferences)
+		references = references[:maxResolvedReferences]
+	}
+
+	resolved := make([]string, 0, len(references))
+	unresolvable := 0
+	for _, reference := range references {
+		issue, err := client.GetIssue(reference.owner, reference.repo, reference.number)
+		switch {
+		case err != nil:
+			logger.Errorf("failed to get the referenced issue %s. cause: %s", reference, err)
+			unresolvable++
+		case issue == nil:
+			resolved = append(resolved, fmt.Sprintf("* `%s` - the issue doesn't exist", reference))
+		case issue.IsPullRequest():
+			resolved = append(resolved, fmt.Sprintf("* `%s`%s - it's a pull request", reference, issueTitle(pr, reference, issue)))
+		case issue.GetState() != "open":
+			resolved = append(resolved, fmt.Sprintf("* `%s`%s - the issue is %s", reference, issueTitle(pr, reference, issue), issue.GetState()))
+		default:
+			return ""
+		}
+	}
+	if unresolvable > 0 {
+		logger.Warnf("%d of the referenced issues couldn't be resolved so the check is skipped", unresolvable)
+		return ""
+	}
+	return fmt.Sprintf(ReferencedIssuesFailureMessage, strings.Join(resolved, "\n"))
+}
+
+// issueTitle formats the title of the given issue as inline code (preceded by a space), so neither mentions nor markdown
+// used in the title take effect in the status message. The title is shown only for the issues of the repository of
+// the PR - the issues of other repositories might be private and are resolved using the token of the bot
+func issueTitle(pr *gogh.PullRequest, reference issueReference, issue *gogh.Issue) string {
+	if !strings.EqualFold(reference.owner, pr.GetBase().GetRepo().GetOwner().GetLogin()) ||
+		!strings.EqualFold(reference.repo, pr.GetBase().GetRepo().GetName()) {
+		return ""
+	}
+	return " `" + strings.Replace(issue.GetTitle(), "`", "'", -1) + "`"
+}
+
+func findIssueReferences(pr *gogh.PullRequest) []issueReference {
+	owner, repo := pr.GetBase().GetRepo().GetOwner().GetLogin(), pr.GetBase().GetRepo().GetName()
+	var references []issueReference
+	found := make(map[issueReference]bool)
+	for _, match := range issueReferenceRegexp.FindAllStringSubmatch(pr.GetBody(), -1) {
+		number, err := strconv.Atoi(match[3])
+		if err != nil {
+			continue
+		}
+		reference := issueReference{owner: owner, repo: repo, number: number}
+		if match[1] != "" {
+			reference.owner, reference.repo = match[1], match[2]
+		}
+		if !found[reference] {
+			found[reference] = true
+			references = append(references, reference)
+		}
+	}
+	return references
+}
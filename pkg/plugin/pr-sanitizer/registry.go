@@ -0,0 +1,146 @@
+package prsanitizer
+
+import (
+	"sort"
+	"sync"
+
+	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	gogh "github.com/google/go-github/v41/github"
+)
+
+const (
+	// SemanticTitleCheck is a name of the check verifying that the PR title follows the semantic message style
+	SemanticTitleCheck = "semantic-title"
+	// DescriptionLengthCheck is a name of the check verifying that the PR description is long enough
+	DescriptionLengthCheck = "description-length"
+	// IssueLinkCheck is a name of the check verifying that the PR description links an issue
+	IssueLinkCheck = "issue-link"
+	// CommitMessagesCheck is a name of the check verifying that the messages of all commits of the PR follow the semantic
+	// message style. It's disabled by default
+	CommitMessagesCheck = "commit-messages"
+	// ReferencedIssuesCheck is a name of the check verifying that any of the issues referenced in the PR description
+	// is an existing open issue. It's disabled by default
+	ReferencedIssuesCheck = "referenced-issues"
+)
+
+const (
+	// SeverityError is a severity of a check which fails the status when the PR doesn't comply with it (the default one)
+	SeverityError = "error"
+	// SeverityWarning is a severity of a check which is only reported in the status message when the PR doesn't comply with it
+	SeverityWarning = "warning"
+)
+
+// Check verifies the given PR and returns a message explaining what is necessary to fix or an empty string when the PR complies
+type Check func(pr *gogh.PullRequest, config PluginConfiguration, logger log.Logger) string
+
+// ClientCheck is a Check which needs to retrieve additional information about the PR using the GitHub client
+type ClientCheck func(client ghclient.Client, pr *gogh.PullRequest, config PluginConfiguration, logger log.Logger) string
+
+type namedCheck struct {
+	name     string
+	check    ClientCheck
+	disabled bool
+}
+
+func withoutClient(check Check) ClientCheck {
+	return func(client ghclient.Client, pr *gogh.PullRequest, config PluginConfiguration, logger log.Logger) string {
+		return check(pr, config, logger)
+	}
+}
+
+// registeredChecks are executed for every PR in the order of registration unless disabled in the configuration.
+// Those which are disabled by default have to be enabled in the configuration explicitly. The registry is guarded
+// by registryLock as the checks can be (un)registered while the events are being handled
+var (
+	registryLock     sync.RWMutex
+	registeredChecks = []namedCheck{
+		{name: SemanticTitleCheck, check: withoutClient(CheckSemanticTitle)},
+		{name: DescriptionLengthCheck, check: withoutClient(CheckDescriptionLength)},
+		{name: IssueLinkCheck, check: withoutClient(CheckIssueLinkPresence)},
+		{name: CommitMessagesCheck, check: CheckCommitMessages, disabled: true},
+		{name: ReferencedIssuesCheck, check: CheckReferencedIssues, disabled: true},
+	}
+)
+
+// RegisterCheck registers the given check under the given name so it's executed for every PR unless disabled
+// in the configuration. When there is already a check registered with the same name, then it's replaced
+func RegisterCheck(name string, check Check) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	for i, registered := range registeredChecks {
+		if registered.name == name {
+			registeredChecks[i].check = withoutClient(check)
+			return
+		}
+	}
+	registeredChecks = append(registeredChecks, namedCheck{name: name, check: withoutClient(check)})
+}
+
+// UnregisterCheck removes the check registered under the given name so it's not executed anymore
+func UnregisterCheck(name string) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	for i, registered := range registeredChecks {
+		if registered.name == name {
+			registeredChecks = append(registeredChecks[:i:i], registeredChecks[i+1:]...)
+			return
+		}
+	}
+}
+
+// RegisteredChecks returns names of all registered checks in the order they are executed in
+func RegisteredChecks() []string {
+	checks := snapshotChecks()
+	names := make([]string, 0, len(checks))
+	for _, registered := range checks {
+		names = append(names, registered.name)
+	}
+	return names
+}
+
+// snapshotChecks returns a copy of the registered checks so they can be executed without holding the lock
+func snapshotChecks() []namedCheck {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return append([]namedCheck(nil), registeredChecks...)
+}
+
+// executeChecks runs all enabled checks and returns the messages of the failed ones split by their severity
+func executeChecks(client ghclient.Client, pr *gogh.PullRequest, config PluginConfiguration, logger log.Logger) (errors, warnings []string) {
+	checks := snapshotChecks()
+	warnAboutUnknownChecks(checks, config, logger)
+	for _, registered := range checks {
+		checkConfig := config.Checks[registered.name]
+		if !checkConfig.enabled(!registered.disabled) {
+			continue
+		}
+		msg := registered.check(client, pr, config, logger)
+		if msg == "" {
+			continue
+		}
+		if checkConfig.severity(logger, registered.name) == SeverityWarning {
+			warnings = append(warnings, msg)
+		} else {
+			errors = append(errors, msg)
+		}
+	}
+	return errors, warnings
+}
+
+func warnAboutUnknownChecks(checks []namedCheck, config PluginConfiguration, logger log.Logger) {
+	known := make(map[string]bool, len(checks))
+	for _, registered := range checks {
+		known[registered.name] = true
+	}
+	var unknown []string
+	for name := range config.Checks {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		logger.Warnf("there is no check with name %q, the configuration is ignored. known checks: %v", name, RegisteredChecks())
+	}
+}
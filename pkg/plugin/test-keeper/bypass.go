@@ -0,0 +1,204 @@
+package testkeeper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/command"
+	"github.com/arquillian/ike-prow-plugins/pkg/github"
+	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	"github.com/arquillian/ike-prow-plugins/pkg/scm"
+	"github.com/arquillian/ike-prow-plugins/pkg/utils"
+	gogh "github.com/google/go-github/v41/github"
+)
+
+// bypass holds information about a valid /ok-without-tests command or a bypass label found in the pull request
+type bypass struct {
+	user   string
+	reason string
+	label  string
+	at     time.Time
+}
+
+func newBypass(source *command.CmdSource) *bypass {
+	reason, _ := ParseBypassReason(source.Arguments(), false)
+	return &bypass{user: source.Author, reason: reason, at: source.UpdatedAt}
+}
+
+func (b *bypass) description() string {
+	switch {
+	case b.label != "":
+		return fmt.Sprintf(ApprovedByLabelMessage, b.user, b.label)
+	case b.reason != "":
+		return fmt.Sprintf(ApprovedByWithReasonMessage, b.user, b.reason)
+	default:
+		return fmt.Sprintf(ApprovedByMessage, b.user)
+	}
+}
+
+// labelBypasses returns the bypass labels the pull request is labeled with (the latest first) and which were applied by
+// users with sufficient permissions. The users are retrieved from the issue events timeline, only the latest labeling
+// of each label is taken into account
+func (gh *GitHubTestEventsHandler) labelBypasses(logger log.Logger, issue scm.RepositoryIssue, pr *gogh.PullRequest,
+	prLoader *ghservice.PullRequestLazyLoader, policy BypassPolicyLoader, permissions command.PermissionsLoader) []*bypass {
+	if len(pr.Labels) == 0 {
+		return nil
+	}
+	applied := make(map[string]bool)
+	for _, label := range pr.Labels {
+		if utils.Contains(policy().BypassLabels, label.GetName()) {
+			applied[label.GetName()] = true
+		}
+	}
+	if len(applied) == 0 {
+		return nil
+	}
+
+	events, err := gh.Client.ListIssueEvents(issue)
+	if err != nil {
+		logger.Errorf("Getting all issue events failed with an error: %s", err)
+		return nil
+	}
+
+	var bypasses []*bypass
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		label := event.GetLabel().GetName()
+		if event.GetEvent() != github.ActionLabeled || !applied[label] {
+			continue
+		}
+		delete(applied, label)
+		if isAllowedToBypass(event.GetActor().GetLogin(), prLoader, permissions) {
+			bypasses = append(bypasses, &bypass{user: event.GetActor().GetLogin(), label: label, at: event.GetCreatedAt()})
+		}
+	}
+	return bypasses
+}
+
+// bypassExpiration checks if the bypasses found in the pull request expired because of the commits pushed after they were
+// given. The matcher and the commits of the pull request are loaded only once (when the first bypass is checked),
+// the statuses of each of the commits are listed at most once and the changes are compared only once for each of
+// the bypassed commits
+type bypassExpiration struct {
+	gh            *GitHubTestEventsHandler
+	logger        log.Logger
+	pr            *gogh.PullRequest
+	configuration *PluginConfiguration
+	loaded        bool
+	err           error
+	matcher       TestMatcher
+	commits       []verifiedCommit
+	listed        int
+	expired       map[string]bool
+}
+
+// verifiedCommit is a commit of the pull request together with the time it was verified by the plugin for the first
+// time - it's zero when the commit hasn't been verified
+type verifiedCommit struct {
+	sha string
+	at  time.Time
+}
+
+func (gh *GitHubTestEventsHandler) newBypassExpiration(logger log.Logger, pr *gogh.PullRequest,
+	configuration *PluginConfiguration) *bypassExpiration {
+	return &bypassExpiration{gh: gh, logger: logger, pr: pr, configuration: configuration, expired: make(map[string]bool)}
+}
+
+// isExpired checks if there were commits pushed after the bypass was given that touch files which are not excluded
+// from the test verification. The changes are retrieved by comparing the commit the bypass was given for with the head
+// of the pull request. When the changes can't be retrieved, the bypass is considered as valid
+func (e *bypassExpiration) isExpired(bypass *bypass) bool {
+	base, err := e.bypassedCommit(bypass)
+	if err != nil {
+		e.logger.Errorf("failed to find the commit the bypass given by %s belongs to so it is considered as valid. cause: %s", bypass.user, err)
+		return false
+	}
+
+	head := e.pr.GetHead().GetSHA()
+	if base == head {
+		return false
+	}
+	if base == "" {
+		// none of the commits existed when the bypass was given (e.g. the branch has been rebased) so the whole change-set is new
+		return true
+	}
+	if expired, compared := e.expired[base]; compared {
+		return expired
+	}
+
+	owner, repo := e.pr.GetBase().GetRepo().GetOwner().GetLogin(), e.pr.GetBase().GetRepo().GetName()
+	changedFiles, err := e.gh.Client.CompareCommits(owner, repo, base, head)
+	if err != nil {
+		e.logger.Errorf("failed to compare %s...%s so the bypass given by %s is considered as valid. cause: %s", base, head, bypass.user, err)
+		return false
+	}
+	e.expired[base] = false
+	for _, file := range changedFiles {
+		if !e.matcher.MatchesExclusion(file.Name) {
+			e.expired[base] = true
+			break
+		}
+	}
+	return e.expired[base]
+}
+
+// bypassedCommit finds the head of the pull request at the time the bypass was given - it is the latest commit
+// of the pull request which had been already verified by the plugin. Only the time of the statuses is taken into account
+// as it is set by GitHub, whereas the commit dates are in the hands of the author. Returns an empty string when there
+// is no such commit
+func (e *bypassExpiration) bypassedCommit(bypass *bypass) (string, error) {
+	if err := e.load(); err != nil {
+		return "", err
+	}
+	for i := range e.commits {
+		if i == e.listed {
+			if err := e.listStatuses(i); err != nil {
+				return "", err
+			}
+		}
+		if commit := e.commits[i]; !commit.at.IsZero() && !commit.at.After(bypass.at) {
+			return commit.sha, nil
+		}
+	}
+	return "", nil
+}
+
+// load builds the matcher and retrieves the commits of the pull request (the latest first)
+func (e *bypassExpiration) load() error {
+	if e.loaded {
+		return e.err
+	}
+	e.loaded = true
+	if e.matcher, e.err = LoadMatcher(e.configuration); e.err != nil {
+		return e.err
+	}
+
+	owner, repo := e.pr.GetBase().GetRepo().GetOwner().GetLogin(), e.pr.GetBase().GetRepo().GetName()
+	commits, err := e.gh.Client.ListPullRequestCommits(owner, repo, e.pr.GetNumber())
+	if err != nil {
+		e.err = err
+		return err
+	}
+	for i := len(commits) - 1; i >= 0; i-- {
+		e.commits = append(e.commits, verifiedCommit{sha: commits[i].GetSHA()})
+	}
+	return nil
+}
+
+// listStatuses sets the time the commit with the given index was verified by the plugin for the first time
+func (e *bypassExpiration) listStatuses(index int) error {
+	owner, repo := e.pr.GetBase().GetRepo().GetOwner().GetLogin(), e.pr.GetBase().GetRepo().GetName()
+	statuses, err := e.gh.Client.ListStatuses(owner, repo, e.commits[index].sha)
+	if err != nil {
+		return err
+	}
+	statusContext := fmt.Sprintf("%s/%s", e.gh.BotName, ProwPluginName)
+	for _, status := range statuses {
+		if status.GetContext() == statusContext && (e.commits[index].at.IsZero() || status.GetCreatedAt().Before(e.commits[index].at)) {
+			e.commits[index].at = status.GetCreatedAt()
+		}
+	}
+	e.listed++
+	return nil
+}
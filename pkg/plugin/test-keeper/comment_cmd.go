@@ -1,61 +1,174 @@
 package testkeeper
 
 import (
+	"fmt"
 	"strings"
 
 	is "github.com/arquillian/ike-prow-plugins/pkg/command"
 	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
 	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
 	"github.com/arquillian/ike-prow-plugins/pkg/log"
-	gogh "github.com/google/go-github/v41/github"
 )
 
-// BypassCheckComment is used as a command to bypass test presence validation
-const BypassCheckComment = "/ok-without-tests"
+const (
+	// BypassCheckComment is used as a command to bypass test presence validation
+	BypassCheckComment = "/ok-without-tests"
+	// BypassReasonPrefix precedes the reason given in the bypass command, e.g. "/ok-without-tests because: docs-only refactor"
+	BypassReasonPrefix = "because:"
+)
 
 // BypassCmd represents a command that is triggered by "/ok-without-tests"
 type BypassCmd struct {
 	userPermissionService *is.PermissionService
+	prLoader              *ghservice.PullRequestLazyLoader
+	permissions           is.PermissionsLoader
+	feedback              is.FeedbackLoader
+	policy                BypassPolicyLoader
 	whenDeleted           is.DoFunction
 	whenAddedOrEdited     is.DoFunction
 }
 
-// Perform executes the set DoFunctions for the given IssueCommentEvent (when all conditions are fulfilled)
-func (c *BypassCmd) Perform(client ghclient.Client, logger log.Logger, comment *gogh.IssueCommentEvent) error {
-	user := c.userPermissionService
-	var BypassCommand = &is.CmdExecutor{Command: BypassCheckComment}
+// Perform executes the set DoFunctions for the given CmdSource (when all conditions are fulfilled)
+func (c *BypassCmd) Perform(client ghclient.Client, logger log.Logger, source *is.CmdSource) error {
+	var BypassCommand = &is.CmdExecutor{Command: BypassCheckComment, Feedback: c.feedback, ValidateArguments: c.validateReason,
+		PullRequest: c.prLoader}
 
 	BypassCommand.When(is.Deleted).By(is.Anybody).Then(c.whenDeleted)
 
 	BypassCommand.
 		When(is.Triggered).
-		By(whoCanTrigger(user)...).
+		By(c.WhoCanTrigger()).
 		Then(c.whenAddedOrEdited)
 
-	return BypassCommand.Execute(client, logger, comment)
+	return BypassCommand.Execute(client, logger, source)
 }
 
-// Matches returns true when the given IssueCommentEvent content is same as "/ok-without-tests"
-func (c *BypassCmd) Matches(comment *gogh.IssueCommentEvent) bool {
-	body := strings.TrimSpace(*comment.Comment.Body)
-	return body == BypassCheckComment
+// Matches returns true when the given CmdSource content prefix is "/ok-without-tests"
+func (c *BypassCmd) Matches(source *is.CmdSource) bool {
+	return strings.Split(source.CommandLine(), " ")[0] == BypassCheckComment
 }
 
-func whoCanTrigger(user *is.PermissionService) []is.PermissionCheck {
-	return []is.PermissionCheck{is.AnyOf(user.Admin, user.PRReviewer, user.PRApprover), is.Not(user.PRCreator)}
+func (c *BypassCmd) validateReason(arguments []string) error {
+	_, err := ParseBypassReason(arguments, c.policy != nil && c.policy().RequireBypassReason)
+	return err
 }
 
-// IsValidBypassCmd checks if the given comment contains expected string and was added by user with sufficient permissions
-func IsValidBypassCmd(comment *gogh.IssueComment, prLoader *ghservice.PullRequestLazyLoader) bool {
-	if BypassCheckComment != strings.TrimSpace(*comment.Body) {
-		return false
+// ParseBypassReason extracts the reason from the arguments of the bypass command ("because: <reason>").
+// When there are no arguments then the reason is empty - unless it's required, then an error is returned.
+func ParseBypassReason(arguments []string, required bool) (string, error) {
+	if len(arguments) == 0 {
+		if required {
+			return "", fmt.Errorf("the reason is required, e.g. `%s %s docs-only refactor`", BypassCheckComment, BypassReasonPrefix)
+		}
+		return "", nil
+	}
+	text := strings.Join(arguments, " ")
+	if !strings.HasPrefix(text, BypassReasonPrefix) {
+		return "", fmt.Errorf("the command accepts only a reason in the form `%s <reason>`, but got `%s`", BypassReasonPrefix, text)
 	}
+	reason := strings.TrimSpace(strings.TrimPrefix(text, BypassReasonPrefix))
+	if reason == "" {
+		return "", fmt.Errorf("the reason following `%s` can't be empty", BypassReasonPrefix)
+	}
+	return reason, nil
+}
 
-	user := is.NewPermissionService(prLoader.Client, *comment.User.Login, prLoader)
+// Description provides the usage and the description of the /ok-without-tests command
+func (c *BypassCmd) Description() is.CmdDescription {
+	return is.CmdDescription{
+		Usage:       fmt.Sprintf("%s [%s <reason>]", BypassCheckComment, BypassReasonPrefix),
+		Description: "Marks the test-keeper status as successful even though there are no tests in the pull request",
+	}
+}
+
+// WhoCanTrigger returns the permission check configured for the /ok-without-tests command. If there is none, then it
+// allows admin, requested reviewer or approver (but not the creator) of the pull request to trigger the command
+func (c *BypassCmd) WhoCanTrigger() is.PermissionCheck {
+	return whoCanTrigger(c.userPermissionService, c.permissions)
+}
+
+func whoCanTrigger(user *is.PermissionService, permissions is.PermissionsLoader) is.PermissionCheck {
+	return is.ConfiguredOrDefault(BypassCheckComment, user, permissions,
+		is.AllOf(is.AnyOf(user.Admin, user.PRReviewer, user.PRApprover), is.Not(user.PRCreator)))
+}
+
+// IsValidBypassCmd checks if the given source (comment or review) contains expected command with a valid reason (if required
+// by the configuration) and was added by user with sufficient permissions (as configured in the command permissions
+// or the default ones)
+func IsValidBypassCmd(source *is.CmdSource, prLoader *ghservice.PullRequestLazyLoader, policy BypassPolicyLoader,
+	permissions is.PermissionsLoader) bool {
+	if BypassCheckComment != strings.Split(source.CommandLine(), " ")[0] {
+		return false
+	}
+	if _, err := ParseBypassReason(source.Arguments(), policy().RequireBypassReason); err != nil {
+		return false
+	}
+	return isAllowedToBypass(source.Author, prLoader, permissions)
+}
 
-	status, err := is.AllOf(whoCanTrigger(user)...)(true)
+// isAllowedToBypass checks if the given user has sufficient permissions to bypass the check (as configured in the command
+// permissions or the default ones)
+func isAllowedToBypass(userName string, prLoader *ghservice.PullRequestLazyLoader, permissions is.PermissionsLoader) bool {
+	user := is.NewPermissionService(prLoader.Client, userName, prLoader)
+	status, err := whoCanTrigger(user, permissions)(true)
 	if err != nil || !status.UserIsApproved {
 		return false
 	}
 	return true
 }
+
+const (
+	// ExplainComment is used as a command to list the categories of all changed files together with the matching patterns
+	ExplainComment = "/test-keeper explain"
+	// ExplainMessage is a message used in a comment replying to the explain command
+	ExplainMessage = "Hey @%s! This is how `%s` has categorized the files changed in this pull request:\n\n%s"
+	// ExplainTruncatedMessage is a message used in a comment replying to the explain command when the pull request
+	// changes more files than GitHub lists
+	ExplainTruncatedMessage = "Hey @%s! This pull request changes too many files to explain how `%s` has categorized them.\n\n%s"
+)
+
+// ExplainCmd represents a command that is triggered by "/test-keeper explain" and replies with a table of all changed
+// files together with their categories and the patterns they are matched by
+type ExplainCmd struct {
+	userPermissionService *is.PermissionService
+	permissions           is.PermissionsLoader
+	feedback              is.FeedbackLoader
+	explain               func() (string, error)
+}
+
+// Perform adds a comment containing the explanation of the file categories for the given CmdSource
+func (c *ExplainCmd) Perform(client ghclient.Client, logger log.Logger, source *is.CmdSource) error {
+	var ExplainCommand = &is.CmdExecutor{Command: ExplainComment, Feedback: c.feedback}
+
+	ExplainCommand.
+		When(is.Triggered).
+		By(c.WhoCanTrigger()).
+		Then(func() error {
+			explanation, err := c.explain()
+			if err != nil {
+				return err
+			}
+			return source.CommentService(client).AddComment(&explanation)
+		})
+
+	return ExplainCommand.Execute(client, logger, source)
+}
+
+// Matches returns true when the given CmdSource content is "/test-keeper explain"
+func (c *ExplainCmd) Matches(source *is.CmdSource) bool {
+	return source.CommandLine() == ExplainComment
+}
+
+// Description provides the usage and the description of the /test-keeper explain command
+func (c *ExplainCmd) Description() is.CmdDescription {
+	return is.CmdDescription{
+		Usage:       ExplainComment,
+		Description: "Lists all changed files with their categories and the patterns which have classified them",
+	}
+}
+
+// WhoCanTrigger returns the permission check configured for the /test-keeper explain command. If there is none, then
+// anyone is allowed to trigger it
+func (c *ExplainCmd) WhoCanTrigger() is.PermissionCheck {
+	return is.ConfiguredOrDefault(ExplainComment, c.userPermissionService, c.permissions, is.Anybody)
+}
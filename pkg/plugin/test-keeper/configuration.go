@@ -1,19 +1,71 @@
 package testkeeper
 
 import (
+	"sort"
+	"strings"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/command"
 	"github.com/arquillian/ike-prow-plugins/pkg/config"
 	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
 	"github.com/arquillian/ike-prow-plugins/pkg/log"
 	"github.com/arquillian/ike-prow-plugins/pkg/scm"
 )
 
+const (
+	// RemovedTestsFail is a value of removed_tests configuration property making the check fail when tests are removed
+	RemovedTestsFail = "fail"
+	// RemovedTestsWarn is a value of removed_tests configuration property making the check warn when tests are removed
+	RemovedTestsWarn = "warn"
+)
+
 // PluginConfiguration defines inclusion and exclusion patterns set of files will be matched against
 // It's unmarshaled from test-keeper.yml configuration file
 type PluginConfiguration struct {
 	config.PluginConfiguration `yaml:",inline,omitempty"`
-	Inclusions                 []string `yaml:"test_patterns,omitempty"`
-	Exclusions                 []string `yaml:"skip_validation_for,omitempty"`
-	Combine                    bool     `yaml:"combine_defaults,omitempty"`
+	Inclusions                 []string                       `yaml:"test_patterns,omitempty"`
+	Exclusions                 []string                       `yaml:"skip_validation_for,omitempty"`
+	Combine                    bool                           `yaml:"combine_defaults,omitempty"`
+	PatternSyntax              string                         `yaml:"pattern_syntax,omitempty"`
+	Languages                  []string                       `yaml:"languages,omitempty"`
+	MinTestLinesRatio          float64                        `yaml:"min_test_lines_ratio,omitempty"`
+	MinTestFilesRatio          float64                        `yaml:"min_test_files_ratio,omitempty"`
+	SkipValidationBelowLines   int                            `yaml:"skip_validation_below_lines,omitempty"`
+	RemovedTests               string                         `yaml:"removed_tests,omitempty"`
+	RespectGitAttributes       bool                           `yaml:"respect_gitattributes,omitempty"`
+	RequireNewTestCases        bool                           `yaml:"require_new_test_cases,omitempty"`
+	TestCasePatterns           map[string][]string            `yaml:"test_case_patterns,omitempty"`
+	PairingRules               []TestPairingRule              `yaml:"test_pairs,omitempty"`
+	Modules                    map[string]ModuleConfiguration `yaml:"modules,omitempty"`
+}
+
+// BypassPolicy defines how the check can be bypassed. Unlike the rest of the configuration, it's read from the base
+// commit of the pull request (together with the configuration of the commands), so the author of the pull request
+// can't relax it by changing the configuration in the pull request itself
+type BypassPolicy struct {
+	RequireBypassReason      bool     `yaml:"require_bypass_reason,omitempty"`
+	ExpireBypassOnNewCommits bool     `yaml:"expire_bypass_on_new_commits,omitempty"`
+	BypassLabels             []string `yaml:"bypass_labels,omitempty"`
+}
+
+// BypassPolicyLoader loads BypassPolicy configured at the base commit of the pull request
+type BypassPolicyLoader func() *BypassPolicy
+
+// baseConfiguration lazily loads the parts of the configuration read from the base commit of the pull request - the
+// configuration of the commands and the BypassPolicy. Both are loaded from the same file, so it's retrieved only once
+type baseConfiguration struct {
+	*command.ConfigurationLoader
+	policy *BypassPolicy
+}
+
+func newBaseConfiguration(logger log.Logger, prLoader *ghservice.PullRequestLazyLoader) *baseConfiguration {
+	policy := &BypassPolicy{}
+	return &baseConfiguration{ConfigurationLoader: command.NewConfigurationLoader(logger, ProwPluginName, prLoader, policy), policy: policy}
+}
+
+// BypassPolicy returns the BypassPolicy configured at the base commit of the pull request. It can be used as BypassPolicyLoader
+func (c *baseConfiguration) BypassPolicy() *BypassPolicy {
+	c.Load()
+	return c.policy
 }
 
 // LoadConfiguration loads a PluginConfiguration for the given change
@@ -29,5 +81,46 @@ func LoadConfiguration(logger log.Logger, change scm.RepositoryChange) *PluginCo
 		return &configuration
 	}
 
+	if configuration.RemovedTests != "" && configuration.RemovedTests != RemovedTestsFail && configuration.RemovedTests != RemovedTestsWarn {
+		logger.Warnf("unknown removed_tests value %q so %q is used instead. expected one of: %s, %s",
+			configuration.RemovedTests, RemovedTestsWarn, RemovedTestsFail, RemovedTestsWarn)
+		configuration.RemovedTests = RemovedTestsWarn
+	}
+
+	configuration.PairingRules = validTestPairingRules(logger, configuration.PairingRules)
+	modulePaths := make(map[string][]string)
+	for path, module := range configuration.Modules {
+		trimmed := strings.Trim(path, directorySeparator)
+		if trimmed == "" {
+			logger.Warnf("module with an empty path %q is ignored - the files outside of the modules are verified "+
+				"using the repository configuration", path)
+			delete(configuration.Modules, path)
+			continue
+		}
+		modulePaths[trimmed] = append(modulePaths[trimmed], path)
+		if len(module.PairingRules) > 0 {
+			module.PairingRules = validTestPairingRules(logger, module.PairingRules)
+			configuration.Modules[path] = module
+		}
+	}
+	for trimmed, paths := range modulePaths {
+		if len(paths) > 1 {
+			sort.Strings(paths)
+			logger.Errorf("modules %q point to the same directory %q so all of them are ignored", paths, trimmed)
+			for _, path := range paths {
+				delete(configuration.Modules, path)
+			}
+		}
+	}
+
 	return &configuration
 }
+
+// patternSyntax returns the syntax the configured file patterns are written in. When it's not set, then the legacy one
+// is used so the patterns of the existing configurations keep their meaning
+func (c *PluginConfiguration) patternSyntax() string {
+	if c.PatternSyntax == "" {
+		return LegacySyntax
+	}
+	return c.PatternSyntax
+}
@@ -74,6 +74,90 @@ var _ = Describe("Test keeper config loader features", func() {
 			Expect(configuration.Combine).To(BeTrue())
 		})
 
+		It("should load module configurations combining their patterns with defaults unless stated otherwise", func() {
+			// given
+			change := scm.RepositoryChange{
+				Owner:    "owner",
+				RepoName: "repo",
+				Hash:     "46cb8fac44709e4ccaae97448c65e8f7320cfea7",
+			}
+
+			mocker.AddConfig(ConfigYml(`modules:
+  services/billing/:
+    test_patterns: ['**/*Spec.java']
+    min_test_lines_ratio: 0.5
+  web/:
+    test_patterns: ['**/*.spec.ts']
+    inherit_patterns: false
+  /:
+    test_patterns: ['**/*Check.java']
+`)).ToChange(change)
+
+			// when
+			configuration := testkeeper.LoadConfiguration(logger, change)
+
+			// then
+			Expect(configuration.Modules).To(HaveLen(2))
+			Expect(configuration.Modules["services/billing/"].Inclusions).To(ConsistOf("**/*Spec.java"))
+			Expect(configuration.Modules["services/billing/"].MinTestLinesRatio).To(Equal(0.5))
+			Expect(configuration.Modules["services/billing/"].InheritPatterns).To(BeTrue())
+			Expect(configuration.Modules["web/"].InheritPatterns).To(BeFalse())
+			Expect(configuration.Modules).ToNot(HaveKey("/"))
+		})
+
+		It("should ignore modules pointing to the same directory", func() {
+			// given
+			change := scm.RepositoryChange{
+				Owner:    "owner",
+				RepoName: "repo",
+				Hash:     "46cb8fac44709e4ccaae97448c65e8f7320cfea7",
+			}
+
+			mocker.AddConfig(ConfigYml(`modules:
+  core:
+    min_test_lines_ratio: 0.5
+  core/:
+    min_test_lines_ratio: 0.1
+  web/:
+    min_test_lines_ratio: 0.2
+`)).ToChange(change)
+
+			// when
+			configuration := testkeeper.LoadConfiguration(logger, change)
+
+			// then
+			Expect(configuration.Modules).To(HaveLen(1))
+			Expect(configuration.Modules).To(HaveKey("web/"))
+		})
+
+		It("should keep test pair templates containing characters with a special meaning in regexps", func() {
+			// given
+			change := scm.RepositoryChange{
+				Owner:    "owner",
+				RepoName: "repo",
+				Hash:     "46cb8fac44709e4ccaae97448c65e8f7320cfea7",
+			}
+
+			mocker.AddConfig(ConfigYml(`test_pairs:
+  - source: src/**/*.go
+    tests: ['{dir}/{name}_test.go', '{dir}/Test\p{name}.go']
+modules:
+  web/:
+    test_pairs:
+      - source: '**/*.ts'
+        tests: ['{dir}/({name}).spec.ts']
+`)).ToChange(change)
+
+			// when
+			configuration := testkeeper.LoadConfiguration(logger, change)
+
+			// then
+			Expect(configuration.PairingRules).To(ConsistOf(
+				testkeeper.TestPairingRule{Source: "src/**/*.go", Tests: []string{"{dir}/{name}_test.go", "{dir}/Test\\p{name}.go"}}))
+			Expect(configuration.Modules["web/"].PairingRules).To(ConsistOf(
+				testkeeper.TestPairingRule{Source: "**/*.ts", Tests: []string{"{dir}/({name}).spec.ts"}}))
+		})
+
 		It("should not load test-keeper configuration yaml file and return empty url when config is not accessible", func() {
 			// given
 			NonExistingRawGitHubFiles(".ike-prow/test-keeper.yml", ".ike-prow/test-keeper.yaml")
@@ -1,7 +1,10 @@
 package testkeeper
 
 import (
+	"fmt"
+
 	"github.com/arquillian/ike-prow-plugins/pkg/command"
+	"github.com/arquillian/ike-prow-plugins/pkg/github"
 	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
 	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
 	"github.com/arquillian/ike-prow-plugins/pkg/log"
@@ -13,16 +16,17 @@ import (
 // GitHubTestEventsHandler is the event handler for the plugin.
 // Implements server.GitHubEventHandler interface which contains the logic for incoming GitHub events
 type GitHubTestEventsHandler struct {
-	Client  ghclient.Client
-	BotName string
+	Client    ghclient.Client
+	BotName   string
+	languages languagesCache
 }
 
 // ProwPluginName is an external prow plugin name used to register this service
 const ProwPluginName = "test-keeper"
 
 var (
-	handledPrActions      = []string{"opened", "reopened", "edited", "synchronize"}
-	handledCommentActions = []string{"created", "edited", "deleted"}
+	handledPrActions      = []string{"opened", "reopened", "edited", "synchronize", "labeled", "unlabeled"}
+	handledCommentActions = []string{"created", "edited", "submitted", "deleted"}
 )
 
 // HandlePullRequestEvent is an entry point for the plugin logic. This method is invoked by the Server when
@@ -31,33 +35,96 @@ func (gh *GitHubTestEventsHandler) HandlePullRequestEvent(logger log.Logger, eve
 	if !utils.Contains(handledPrActions, *event.Action) {
 		return nil
 	}
-	return gh.checkTestsAndSetStatus(logger, ghservice.NewPullRequestLazyLoaderWithPR(gh.Client, event.PullRequest))
+	base := newBaseConfiguration(logger, ghservice.NewPullRequestLazyLoaderWithPR(gh.Client, event.PullRequest))
+	if isLabelAction(*event.Action) && !utils.Contains(base.BypassPolicy().BypassLabels, event.GetLabel().GetName()) {
+		return nil
+	}
+	configuration := LoadConfiguration(logger, ghservice.NewRepositoryChangeForPR(event.PullRequest))
+	return gh.checkTestsAndSetStatus(logger, event.PullRequest, configuration, base)
+}
+
+func isLabelAction(action string) bool {
+	return action == github.ActionLabeled || action == github.ActionUnlabeled
 }
 
 // HandleIssueCommentEvent is an entry point for the plugin logic. This method is invoked by the Server when
 // issue comment event is dispatched from the /hook service
 func (gh *GitHubTestEventsHandler) HandleIssueCommentEvent(logger log.Logger, comment *gogh.IssueCommentEvent) error {
-	if !utils.Contains(handledCommentActions, *comment.Action) {
+	return gh.handleCommand(logger, command.NewIssueCommentSource(comment))
+}
+
+// HandlePullRequestReviewEvent is an entry point for the plugin logic. This method is invoked by the Server when
+// pull request review event is dispatched from the /hook service
+func (gh *GitHubTestEventsHandler) HandlePullRequestReviewEvent(logger log.Logger, review *gogh.PullRequestReviewEvent) error {
+	return gh.handleCommand(logger, command.NewReviewSource(review))
+}
+
+// HandlePullRequestReviewCommentEvent is an entry point for the plugin logic. This method is invoked by the Server when
+// pull request review comment event is dispatched from the /hook service
+func (gh *GitHubTestEventsHandler) HandlePullRequestReviewCommentEvent(logger log.Logger, comment *gogh.PullRequestReviewCommentEvent) error {
+	return gh.handleCommand(logger, command.NewReviewCommentSource(comment))
+}
+
+func (gh *GitHubTestEventsHandler) handleCommand(logger log.Logger, source *command.CmdSource) error {
+	if !utils.Contains(handledCommentActions, source.Action) {
 		return nil
 	}
 
-	prLoader := ghservice.NewPullRequestLazyLoaderFromComment(gh.Client, comment)
-	userPerm := command.NewPermissionService(gh.Client, *comment.Sender.Login, prLoader)
+	prLoader := source.PullRequestLoader(gh.Client)
+	userPerm := command.NewPermissionService(gh.Client, source.Author, prLoader)
+	base := newBaseConfiguration(logger, prLoader)
+	// the configuration is loaded only once per event - even when more commands use it
+	var loaded *PluginConfiguration
+	loadConfiguration := func() (*gogh.PullRequest, *PluginConfiguration, error) {
+		pullRequest, err := prLoader.Load()
+		if err != nil {
+			return nil, nil, err
+		}
+		if loaded == nil {
+			loaded = LoadConfiguration(logger, ghservice.NewRepositoryChangeForPR(pullRequest))
+		}
+		return pullRequest, loaded, nil
+	}
+
+	checkTestsAndSetStatus := func() error {
+		pullRequest, configuration, err := loadConfiguration()
+		if err != nil {
+			return err
+		}
+		return gh.checkTestsAndSetStatus(logger, pullRequest, configuration, base)
+	}
+
+	explain := func() (string, error) {
+		pullRequest, configuration, err := loadConfiguration()
+		if err != nil {
+			return "", err
+		}
+		checks, err := gh.checkTests(logger, pullRequest, configuration)
+		if truncated, partial := err.(*ghclient.TruncatedFilesError); partial {
+			return fmt.Sprintf(ExplainTruncatedMessage, source.Author, ProwPluginName, truncatedFilesReport(truncated)), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(ExplainMessage, source.Author, ProwPluginName, checks.explanation(explainedFilesLimit)), nil
+	}
 
-	cmdHandler := command.CommentCmdHandler{Client: gh.Client}
+	cmdHandler := command.CommentCmdHandler{Client: gh.Client, PluginName: ProwPluginName}
 
 	cmdHandler.Register(&command.RunCmd{
 		PluginName:            ProwPluginName,
 		UserPermissionService: userPerm,
-		WhenAddedOrEdited: func() error {
-			return gh.checkTestsAndSetStatus(logger, prLoader)
-		}})
+		Permissions:           base.Permissions,
+		Feedback:              base.Feedback,
+		WhenAddedOrEdited:     checkTestsAndSetStatus})
 
 	cmdHandler.Register(&BypassCmd{
 		userPermissionService: userPerm,
-		whenDeleted: func() error {
-			return gh.checkTestsAndSetStatus(logger, prLoader)
-		},
+		prLoader:              prLoader,
+		permissions:           base.Permissions,
+		feedback:              base.Feedback,
+		policy:                base.BypassPolicy,
+		whenDeleted:           checkTestsAndSetStatus,
 		whenAddedOrEdited: func() error {
 			pullRequest, err := prLoader.Load()
 			if err != nil {
@@ -65,97 +132,216 @@ func (gh *GitHubTestEventsHandler) HandleIssueCommentEvent(logger log.Logger, co
 			}
 			reportBypassCommand(pullRequest)
 			statusService := gh.newTestStatusService(logger, pullRequest)
-			return statusService.okWithoutTests(*comment.Sender.Login)
+			return statusService.okWithoutTests(newBypass(source))
 		}})
 
-	err := cmdHandler.Handle(logger, comment)
+	cmdHandler.Register(&ExplainCmd{
+		userPermissionService: userPerm,
+		permissions:           base.Permissions,
+		feedback:              base.Feedback,
+		explain:               explain})
+
+	err := cmdHandler.Handle(logger, source)
 	if err != nil {
 		logger.Error(err)
 	}
 	return err
 }
 
+// checkIfBypassed looks for the latest valid bypass in the bypass labels, then in the comments and in the reviews of
+// the pull request. When the bypass policy makes the bypass expire on new commits, then the expired ones are skipped -
+// the latest of them is returned as the second value so it can be reported
 func (gh *GitHubTestEventsHandler) checkIfBypassed(logger log.Logger, commentsLoader *ghservice.IssueCommentsLazyLoader,
-	pr *gogh.PullRequest) (found bool, comment string) {
+	pr *gogh.PullRequest, configuration *PluginConfiguration, base *baseConfiguration) (valid, expired *bypass) {
+	prLoader := ghservice.NewPullRequestLazyLoaderWithPR(gh.Client, pr)
+	policy, permissions := base.BypassPolicy, base.Permissions
+	expiration := gh.newBypassExpiration(logger, pr, configuration)
+	accept := func(found *bypass) bool {
+		if policy().ExpireBypassOnNewCommits {
+			if expiration.isExpired(found) {
+				if expired == nil || expired.at.Before(found.at) {
+					expired = found
+				}
+				return false
+			}
+		}
+		valid = found
+		return true
+	}
+
+	for _, labeled := range gh.labelBypasses(logger, commentsLoader.Issue, pr, prLoader, policy, permissions) {
+		if accept(labeled) {
+			return valid, nil
+		}
+	}
+
 	comments, err := commentsLoader.Load()
 	if err != nil {
 		logger.Errorf("Getting all comments failed with an error: %s", err)
-		return false, ""
+		return nil, expired
 	}
-
-	prLoader := ghservice.NewPullRequestLazyLoaderWithPR(gh.Client, pr)
-	for _, comment := range comments {
-		if IsValidBypassCmd(comment, prLoader) {
-			return true, *comment.User.Login
+	for i := len(comments) - 1; i >= 0; i-- {
+		source := command.NewExistingIssueCommentSource(commentsLoader.Issue, comments[i])
+		if IsValidBypassCmd(source, prLoader, policy, permissions) && accept(newBypass(source)) {
+			return valid, nil
 		}
 	}
-	return false, ""
-}
 
-func (gh *GitHubTestEventsHandler) checkTestsAndSetStatus(logger log.Logger, prLoader *ghservice.PullRequestLazyLoader) error {
-	pr, err := prLoader.Load()
+	reviews, err := gh.Client.GetPullRequestReviews(prLoader.RepoOwner, prLoader.RepoName, prLoader.Number)
 	if err != nil {
-		return err
+		logger.Errorf("Getting all reviews failed with an error: %s", err)
+		return nil, expired
 	}
-	change := ghservice.NewRepositoryChangeForPR(pr)
-	configuration := LoadConfiguration(logger, change)
-	fileCategories, err := gh.checkTests(logger, change, configuration, *pr.Number)
+	for i := len(reviews) - 1; i >= 0; i-- {
+		source := command.NewExistingReviewSource(commentsLoader.Issue, reviews[i])
+		if IsValidBypassCmd(source, prLoader, policy, permissions) && accept(newBypass(source)) {
+			return valid, nil
+		}
+	}
+	return nil, expired
+}
+
+func (gh *GitHubTestEventsHandler) checkTestsAndSetStatus(logger log.Logger, pr *gogh.PullRequest, configuration *PluginConfiguration,
+	base *baseConfiguration) error {
+	checks, err := gh.checkTests(logger, pr, configuration)
 	commentsLoader := ghservice.NewIssueCommentsLazyLoader(gh.Client, pr)
 
 	statusService := gh.newTestStatusServiceWithMessages(logger, pr, commentsLoader, configuration)
-	if err != nil {
+	truncated, partial := err.(*ghclient.TruncatedFilesError)
+	if err != nil && !partial {
 		if statusErr := statusService.reportError(); statusErr != nil {
 			logger.Errorf("failed to report error status on PR [%q]. cause: %s", *pr, statusErr)
 		}
 		return err
 	}
 
-	if fileCategories.OnlySkippedFiles() {
-		statusService.onlySkippedMessage()
-		return statusService.okOnlySkippedFiles()
+	outcome := checks.outcome()
+	report := checks.report()
+	removedTests := checks.removedTests(configuration)
+	removedReport := removedTestsReport(removedTests)
+	failOnRemovedTests := len(removedTests) > 0 && configuration.RemovedTests == RemovedTestsFail
+	truncatedReport := ""
+	if partial {
+		truncatedReport = truncatedFilesReport(truncated)
 	}
+	// when the list of the changed files is partial, then only the presence of the tests can be decided
+	decidable := !partial || outcome == outcomeTestsExist
 
-	if fileCategories.TestsExist() {
-		reportPullRequest(logger, pr, WithTests)
-		statusService.withTestsMessage()
-		return statusService.okTestsExist()
+	if !failOnRemovedTests && decidable {
+		switch outcome {
+		case outcomeOnlySkipped:
+			statusService.onlySkippedMessage()
+			return statusService.okOnlySkippedFiles()
+		case outcomeTestsExist:
+			reportPullRequest(logger, pr, WithTests)
+			statusService.withTestsMessage(report, removedReport, truncatedReport)
+			if len(removedTests) > 0 {
+				return statusService.warnRemovedTests()
+			}
+			return statusService.okTestsExist()
+		case outcomeSmallChange:
+			statusService.smallChangeMessage(report, removedReport)
+			if len(removedTests) > 0 {
+				return statusService.warnRemovedTests()
+			}
+			return statusService.okSmallChange()
+		}
 	}
 
-	bypassed, user := gh.checkIfBypassed(logger, commentsLoader, pr)
-	if bypassed {
+	bypassed, expired := gh.checkIfBypassed(logger, commentsLoader, pr, configuration, base)
+	if bypassed != nil {
 		reportBypassCommand(pr)
-		return statusService.okWithoutTests(user)
+		return statusService.okWithoutTests(bypassed)
 	}
 
-	reportPullRequest(logger, pr, WithoutTests)
-	statusService.withoutTestsMessage()
-	err = statusService.failNoTests()
+	if checks.testsExist() {
+		reportPullRequest(logger, pr, WithTests)
+	} else {
+		reportPullRequest(logger, pr, WithoutTests)
+	}
+	explanation := checks.explanationDetails()
+	switch {
+	case !decidable && !failOnRemovedTests:
+		statusService.tooManyFilesMessage(truncatedReport)
+	case outcome.successful():
+		statusService.removedTestsMessage(report, removedReport, explanation)
+	case outcome == outcomeNoTests:
+		statusService.withoutTestsMessage(report, removedReport, explanation)
+	case outcome == outcomeMissingTests:
+		statusService.missingTestsMessage(report, removedReport, explanation)
+	default:
+		statusService.insufficientTestsMessage(report, removedReport, explanation)
+	}
+	switch {
+	case expired != nil:
+		err = statusService.failBypassExpired(expired.user)
+	case failOnRemovedTests:
+		err = statusService.failRemovedTests()
+	case !decidable:
+		err = statusService.failTooManyFiles()
+	case outcome == outcomeMissingTests:
+		err = statusService.failMissingTests()
+	case outcome == outcomeInsufficientTests:
+		err = statusService.failInsufficientTests()
+	default:
+		err = statusService.failNoTests()
+	}
 	if err != nil {
 		logger.Errorf("failed to report status on PR [%q]. cause: %s", *pr, err)
 	}
 	return err
 }
 
-func (gh *GitHubTestEventsHandler) checkTests(logger log.Logger, change scm.RepositoryChange,
-	config *PluginConfiguration, prNumber int) (FileCategories, error) {
-	matcher, err := LoadMatcher(config)
-	if err != nil {
+// checkTests counts the categories of the files changed in the pull request separately for each of the touched modules
+// using the configuration applicable to the module. Unless the languages are configured, the default patterns are
+// loaded for the languages detected for the repository. When configured, the files marked by the linguist attributes
+// in .gitattributes file of the base revision are skipped. When the pull request changes more files than GitHub lists,
+// then the checks of the listed files are returned together with ghclient.TruncatedFilesError
+func (gh *GitHubTestEventsHandler) checkTests(logger log.Logger, pr *gogh.PullRequest, config *PluginConfiguration) (moduleChecks, error) {
+	change := ghservice.NewRepositoryChangeForPR(pr)
+	changedFiles, err := gh.Client.ListChangedFiles(pr)
+	truncated, partial := err.(*ghclient.TruncatedFilesError)
+	if err != nil && !partial {
 		logger.Error(err)
-		return FileCategories{}, err
+		return nil, err
+	}
+	if partial {
+		logger.Warnf("the check of PR [%d] is based on a partial list of files: %s", pr.GetNumber(), truncated)
 	}
 
-	fileCategoryCounter := FileCategoryCounter{Matcher: matcher}
+	config = gh.withDetectedLanguages(logger, change, config)
 
-	changedFiles, err := gh.Client.ListPullRequestFiles(change.Owner, change.RepoName, prNumber)
-	if err != nil {
-		logger.Error(err)
-		return FileCategories{}, err
+	var attributes GitAttributes
+	if config.RespectGitAttributes {
+		attributes = loadGitAttributes(logger, scm.RepositoryChange{Owner: change.Owner, RepoName: change.RepoName, Hash: pr.GetBase().GetSHA()})
 	}
 
-	fileCategories, err := fileCategoryCounter.Count(changedFiles)
-	if err != nil {
-		logger.Error(err)
+	paths, modules := config.splitIntoModules(changedFiles)
+	checks := make(moduleChecks, 0, len(paths))
+	for _, path := range paths {
+		moduleConfig := config.forModule(path)
+		matcher, err := LoadMatcher(moduleConfig)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		matcher.Attributes = attributes
+
+		fileCategoryCounter := FileCategoryCounter{
+			Matcher:             matcher,
+			PairingRules:        moduleConfig.PairingRules,
+			RequireNewTestCases: moduleConfig.RequireNewTestCases,
+		}
+		fileCategories, err := fileCategoryCounter.Count(modules[path])
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		checks = append(checks, moduleCheck{path: path, configuration: moduleConfig, matcher: matcher, categories: fileCategories})
 	}
 
-	return fileCategories, err
+	if partial {
+		return checks, truncated
+	}
+	return checks, nil
 }
@@ -2,13 +2,16 @@ package testkeeper_test
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/arquillian/ike-prow-plugins/pkg/command"
 	"github.com/arquillian/ike-prow-plugins/pkg/github"
+	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
 	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
 	. "github.com/arquillian/ike-prow-plugins/pkg/internal/test"
 	"github.com/arquillian/ike-prow-plugins/pkg/log"
 	testkeeper "github.com/arquillian/ike-prow-plugins/pkg/plugin/test-keeper"
+	gogh "github.com/google/go-github/v41/github"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	gock "gopkg.in/h2non/gock.v1"
@@ -23,6 +26,10 @@ var _ = Describe("Test Keeper Plugin features", func() {
 
 	log := log.NewTestLogger()
 
+	JustBeforeEach(func() {
+		AnyRepositoryWrittenIn(defaultLanguages...)
+	})
+
 	Context("Pull Request event handling", func() {
 
 		BeforeEach(func() {
@@ -56,6 +63,7 @@ var _ = Describe("Test Keeper Plugin features", func() {
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
 				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/with_tests/changes_go_files.json")).
 				WithoutComments().
+				WithoutReviews().
 				WithConfigFile(
 					ConfigYml(Containing(
 						Param("test_patterns", "['**/*_test_suite.go']"),
@@ -79,6 +87,7 @@ var _ = Describe("Test Keeper Plugin features", func() {
 					ConfigYml(Containing(
 						Param("skip_validation_for", "['**/Randomfile']")))).
 				WithoutComments().
+				WithoutReviews().
 				Expecting(
 					Status(ToBe(github.StatusSuccess, testkeeper.OkOnlySkippedFilesMessage, testkeeper.OkOnlySkippedFilesDetailsPageName)),
 					Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg))).
@@ -94,12 +103,14 @@ var _ = Describe("Test Keeper Plugin features", func() {
 		It("should reject opened pull request when no tests are matching defined pattern with no defaults implicitly combined", func() {
 			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
 				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/with_tests/changes_go_files.json")).
 				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
 				WithConfigFile(
 					ConfigYml(LoadedFrom("test_fixtures/github_calls/prs/with_tests/test-keeper.yml"))).
 				WithoutMessageFiles("test-keeper_without_tests_message.md").
 				WithoutComments().
+				WithoutReviews().
 				Expecting(
 					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName)),
 					Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg))).
@@ -115,10 +126,12 @@ var _ = Describe("Test Keeper Plugin features", func() {
 		It("should block newly created pull request when no tests are included", func() {
 			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
 				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
 				WithoutConfigFiles().
 				WithoutMessageFiles("test-keeper_without_tests_message.md").
 				WithoutComments().
+				WithoutReviews().
 				Expecting(
 					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName)),
 					Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg))).
@@ -137,6 +150,7 @@ var _ = Describe("Test Keeper Plugin features", func() {
 				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/build_and_docs_only_changes.json")).
 				WithoutConfigFiles().
 				WithoutComments().
+				WithoutReviews().
 				Expecting(
 					Status(ToBe(github.StatusSuccess, testkeeper.OkOnlySkippedFilesMessage, testkeeper.OkOnlySkippedFilesDetailsPageName))).
 				Create()
@@ -151,8 +165,10 @@ var _ = Describe("Test Keeper Plugin features", func() {
 		It("should block newly created pull request when deletions in the tests are the only changes", func() {
 			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
 				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/deletions_only_changes_in_tests.json")).
 				WithoutComments().
+				WithoutReviews().
 				WithoutConfigFiles().
 				WithoutMessageFiles("test-keeper_without_tests_message.md").
 				Expecting(
@@ -170,8 +186,10 @@ var _ = Describe("Test Keeper Plugin features", func() {
 		It("should block newly created pull request when there are changes in the business logic but only deletions in the tests", func() {
 			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
 				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/prod_code_changes_with_deletion_only_in_tests.json")).
 				WithoutComments().
+				WithoutReviews().
 				WithoutConfigFiles().
 				WithoutMessageFiles("test-keeper_without_tests_message.md").
 				Expecting(
@@ -186,37 +204,48 @@ var _ = Describe("Test Keeper Plugin features", func() {
 			Ω(err).ShouldNot(HaveOccurred())
 		})
 
-		It("should send ok status when PR contains no test but a comment with bypass command is present", func() {
-			approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "bartoszmajsak")
-
+		It("should block pull request when added tests don't satisfy configured ratio of test lines to production lines", func() {
+			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
-				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
-				WithUsers(Admin("bartoszmajsak")).
-				WithComments(`[{"user":{"login":"bartoszmajsak"}, "body":"` + testkeeper.BypassCheckComment + `"}]`).
+				WithoutBaseConfigFiles().
+				WithFiles(smallTestForBigFeature).
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("min_test_lines_ratio", "0.5")))).
+				WithoutMessageFiles("test-keeper_insufficient_tests_message.md").
+				WithoutComments().
 				WithoutReviews().
-				WithoutConfigFiles().
 				Expecting(
-					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+					Status(ToBe(github.StatusFailure, testkeeper.InsufficientTestsMessage, testkeeper.InsufficientTestsDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains(testkeeper.InsufficientTestsMsg),
+						HaveBodyThatContains("| Added lines | 200 | 5 |"),
+						HaveBodyThatContains("ratio of added test lines to added production lines is 0.03 (required at least 0.50)")))).
 				Create()
 
 			// when
 			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
 
-			// then - should not expect any additional request mocking
+			// then - implicit verification of /statuses call occurrence with proper payload
 			Ω(err).ShouldNot(HaveOccurred())
 		})
 
-		It("should block pull request without tests and with comments containing bypass message added by user with insufficient permissions", func() {
+		It("should block pull request when some of the changed production files are missing paired tests", func() {
 			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
-				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
-				WithUsers(ExternalUser("bartoszmajsak-test")).
-				WithComments(LoadedFrom("test_fixtures/github_calls/prs/comments_with_no_test_status_msg.json")).
+				WithoutBaseConfigFiles().
+				WithFiles(partiallyPairedTests).
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(ConfigYml(pairingRules)).
+				WithoutMessageFiles("test-keeper_missing_tests_message.md").
+				WithoutComments().
 				WithoutReviews().
-				WithoutConfigFiles().
-				WithoutMessageFiles("test-keeper_without_tests_message.md").
 				Expecting(
-					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName))).
+					Status(ToBe(github.StatusFailure, testkeeper.MissingTestsMessage, testkeeper.MissingTestsDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains(testkeeper.MissingTestsMsg),
+						HaveBodyThatContains("* `src/main/java/io/openshift/booster/Greeting.java` - expected a change in `src/test/java/**/GreetingTest.java`")))).
 				Create()
 
 			// when
@@ -225,131 +254,1361 @@ var _ = Describe("Test Keeper Plugin features", func() {
 			// then - implicit verification of /statuses call occurrence with proper payload
 			Ω(err).ShouldNot(HaveOccurred())
 		})
-	})
 
-	Context("Pull Request comment event handling", func() {
+		It("should match backslash in the test template literally", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(partiallyPairedTests).
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(ConfigYml(`test_pairs:
+  - source: src/main/java/**/Greeting.java
+    tests: ['src/test/java/**/Test\p{name}.java']`)).
+				WithoutMessageFiles("test-keeper_missing_tests_message.md").
+				WithoutComments().
+				WithoutReviews().
+				Expecting(
+					Status(ToBe(github.StatusFailure, testkeeper.MissingTestsMessage, testkeeper.MissingTestsDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains("* `src/main/java/io/openshift/booster/Greeting.java` - expected a change in `src/test/java/**/Test\\pGreeting.java`")))).
+				Create()
 
-		BeforeEach(func() {
-			defer gock.OffAll()
-			handler = &testkeeper.GitHubTestEventsHandler{Client: NewDefaultGitHubClient(), BotName: botName}
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
 		})
 
-		AfterEach(EnsureGockRequestsHaveBeenMatched)
+		It("should approve pull request when all changed production files are accompanied by paired tests", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(smallTestForBigFeature).
+				WithConfigFile(ConfigYml(pairingRules)).
+				WithoutComments().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName))).
+				Create()
 
-		It("should skip test existence check when "+testkeeper.BypassCheckComment+" command is used by admin user", func() {
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should block pull request when one of the touched modules doesn't satisfy its own rules", func() {
 			// given
-			approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "bartoszmajsak")
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
-				WithUsers(Admin("bartoszmajsak")).
+				WithoutBaseConfigFiles().
+				WithFiles(monorepoChanges).
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(ConfigYml(strictBillingModule)).
+				WithoutMessageFiles("test-keeper_insufficient_tests_message.md").
+				WithoutComments().
 				WithoutReviews().
-				WithoutConfigFiles().
 				Expecting(
-					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+					Status(ToBe(github.StatusFailure, testkeeper.InsufficientTestsMessage, testkeeper.InsufficientTestsDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains(testkeeper.InsufficientTestsMsg),
+						HaveBodyThatContains("| `services/billing/` | 1 | 1 | :x: not enough tests |"),
+						HaveBodyThatContains("| `web/` | 1 | 1 | :white_check_mark: tests exist |"),
+						HaveBodyThatContains("**`services/billing/`**")))).
 				Create()
 
-			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak"), testkeeper.BypassCheckComment, "created")
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should keep default patterns for the module not inheriting the patterns of the repository", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(`[
+	{"filename":"core/src/main/java/com/acme/Invoice.java", "status":"modified", "additions":60, "deletions":4},
+	{"filename":"core/src/test/java/com/acme/InvoiceTest.java", "status":"modified", "additions":10, "deletions":0}
+]`).
+				WithConfigFile(ConfigYml(`test_patterns: ['**/*Spec.java']
+modules:
+  core/:
+    test_patterns: ['**/*Check.java']
+    inherit_patterns: false
+`)).
+				WithoutComments().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName))).
+				Create()
 
 			// when
-			err := handler.HandleIssueCommentEvent(log, event)
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
 
 			// then - implicit verification of /statuses call occurrence with proper payload
 			Ω(err).ShouldNot(HaveOccurred())
 		})
 
-		It("should ignore "+testkeeper.BypassCheckComment+" when used by non-admin user", func() {
+		It("should approve pull request when each of the touched modules satisfies its own rules", func() {
 			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
-				WithUsers(ExternalUser("bartoszmajsak-test")).
-				WithoutReviews().
-				WithoutConfigFiles().
+				WithFiles(monorepoChanges).
+				WithConfigFile(ConfigYml(lenientBillingModule)).
+				WithoutComments().
 				Expecting(
+					Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName)),
 					Comment(To(
-						HaveBodyThatContains("Hey @bartoszmajsak-test! It seems you tried to trigger `/ok-without-tests` command"),
-						HaveBodyThatContains("You have to be admin or requested reviewer or pull request approver, but not pull request creator"))),
-					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName))).
+						HaveBodyThatContains("| `services/billing/` | 1 | 1 | :white_check_mark: tests exist |"),
+						HaveBodyThatContains("| rest of the repository | 0 | 0 | :white_check_mark: no tests needed |")))).
 				Create()
 
-			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak-test"), testkeeper.BypassCheckComment, "created")
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should approve pull request when all changed files are marked as generated in .gitattributes and it's respected", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(generatedCode).
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("respect_gitattributes", "true")))).
+				WithBaseRawFile(testkeeper.GitAttributesFile, "*.pb.go linguist-generated\napi/openapi/** linguist-generated").
+				WithoutComments().
+				WithoutReviews().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, testkeeper.OkOnlySkippedFilesMessage, testkeeper.OkOnlySkippedFilesDetailsPageName))).
+				Create()
 
 			// when
-			err := handler.HandleIssueCommentEvent(log, event)
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
 
 			// then - implicit verification of /statuses call occurrence with proper payload
 			Ω(err).ShouldNot(HaveOccurred())
 		})
-	})
 
-	Context("Trigger test-keeper plugin by triggering comment on pull request", func() {
-		BeforeEach(func() {
-			defer gock.OffAll()
-			handler = &testkeeper.GitHubTestEventsHandler{Client: NewDefaultGitHubClient(), BotName: botName}
+		It("should block pull request when only some of the changed files are marked as generated in .gitattributes", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(generatedCode).
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("respect_gitattributes", "true")))).
+				WithBaseRawFile(testkeeper.GitAttributesFile, "*.pb.go linguist-generated").
+				WithoutMessageFiles("test-keeper_without_tests_message.md").
+				WithoutComments().
+				WithoutReviews().
+				Expecting(
+					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains("| `pkg/greeting/greeting.pb.go` | skipped | `*.pb.go linguist-generated` |"),
+						HaveBodyThatContains("| `api/openapi/client.go` | production |  |  |")))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
 		})
 
-		AfterEach(EnsureGockRequestsHaveBeenMatched)
+		It("should approve pull request with truncated list of files when tests are found among the listed ones", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithSize(4200).
+				WithFiles(changedFiles(ghclient.PullRequestFilesLimit, "src/test/java/io/openshift/booster/GreetingTest.java")).
+				WithComments(LoadedFrom("test_fixtures/github_calls/prs/comments_with_no_test_status_msg.json")).
+				WithoutConfigFiles().
+				WithoutMessageFiles("test-keeper_with_tests_message.md").
+				Expecting(
+					Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName)),
+					ChangedComment(397622617, To(
+						HaveBodyThatContains(testkeeper.WithTestsMsg),
+						HaveBodyThatContains("GitHub has listed only 3000 of 4200 files changed in this PR")))).
+				Create()
 
-		It("should block newly created pull request without tests when "+command.RunCommentPrefix+" all command is used by admin user", func() {
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should block pull request with truncated list of files when no tests are found among the listed ones", func() {
 			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
-				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithoutBaseConfigFiles().
+				WithSize(4200).
+				WithFiles(changedFiles(ghclient.PullRequestFilesLimit, "README.adoc")).
+				WithoutConfigFiles().
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithoutMessageFiles("test-keeper_too_many_files_message.md").
 				WithoutComments().
-				WithUsers(Admin("bartoszmajsak")).
 				WithoutReviews().
-				WithoutConfigFiles().
-				WithoutMessageFiles("test-keeper_without_tests_message.md").
-				Expecting(Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg)),
-					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName))).
+				Expecting(
+					Status(ToBe(github.StatusFailure, testkeeper.TooManyFilesMessage, testkeeper.TooManyFilesDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains(testkeeper.TooManyFilesMsg),
+						HaveBodyThatContains("GitHub has listed only 3000 of 4200 files changed in this PR")))).
 				Create()
 
-			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak"), "/run all", "created")
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should block pull request when changed tests don't add any new test case and new test cases are required", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(testChangedWithoutNewCases).
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("require_new_test_cases", "true")))).
+				WithoutMessageFiles("test-keeper_without_tests_message.md").
+				WithoutComments().
+				WithoutReviews().
+				Expecting(
+					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains("no new test case has been added to them"),
+						HaveBodyThatContains("* `src/test/java/io/openshift/booster/GreetingTest.java`")))).
+				Create()
 
 			// when
-			err := handler.HandleIssueCommentEvent(log, event)
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
 
 			// then - implicit verification of /statuses call occurrence with proper payload
 			Ω(err).ShouldNot(HaveOccurred())
 		})
 
-		It("should approve newly created pull request with tests when "+command.RunCommentPrefix+" "+testkeeper.ProwPluginName+" command is triggered by pr reviewer", func() {
+		It("should block pull request removing more tests than it adds when configured to fail on removed tests", func() {
 			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
-				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/with_tests/changes.json")).
+				WithoutBaseConfigFiles().
+				WithFiles(removedTests).
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("removed_tests", testkeeper.RemovedTestsFail)))).
+				WithoutMessageFiles("test-keeper_removed_tests_message.md").
 				WithoutComments().
-				WithUsers(ExternalUser("bartoszmajsak-test"), RequestedReviewer("bartoszmajsak-test")).
 				WithoutReviews().
-				WithoutConfigFiles().
-				Expecting(Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg)),
-					Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName))).
+				Expecting(
+					Status(ToBe(github.StatusFailure, testkeeper.RemovedTestsMessage, testkeeper.RemovedTestsDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains(testkeeper.RemovedTestsMsg),
+						HaveBodyThatContains("* `src/test/java/io/openshift/booster/HttpApplicationTest.java`"),
+						HaveBodyThatContains("* `src/test/java/io/openshift/booster/GreetingTest.java`")))).
 				Create()
 
-			event := prMock.CreateCommentEvent(SentByReviewer, "/run test-keeper", "created")
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should approve pull request removing tests with a warning when configured to warn on removed tests", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(removedTests).
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("removed_tests", testkeeper.RemovedTestsWarn)))).
+				WithoutMessageFiles("test-keeper_with_tests_message.md").
+				WithoutComments().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, testkeeper.RemovedTestsWarningMessage, testkeeper.RemovedTestsDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains(testkeeper.WithTestsMsg),
+						HaveBodyThatContains("* `src/test/java/io/openshift/booster/HttpApplicationTest.java`")))).
+				Create()
 
 			// when
-			err := handler.HandleIssueCommentEvent(log, event)
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
 
 			// then - implicit verification of /statuses call occurrence with proper payload
 			Ω(err).ShouldNot(HaveOccurred())
 		})
 
-		It("should do nothing for newly created pull request with tests when "+command.RunCommentPrefix+" work-in-progress command is triggered by pr reviewer", func() {
+		It("should approve pull request removing tests with a warning when unknown value of removed tests is configured", func() {
 			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
-				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/with_tests/changes.json")).
+				WithFiles(removedTests).
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("removed_tests", "block")))).
+				WithoutMessageFiles("test-keeper_with_tests_message.md").
 				WithoutComments().
-				WithUsers(ExternalUser("bartoszmajsak-test"), RequestedReviewer("bartoszmajsak-test")).
-				WithoutReviews().
-				WithoutConfigFiles().
 				Expecting(
-					NoStatus(),
-					NoComment()).
+					Status(ToBe(github.StatusSuccess, testkeeper.RemovedTestsWarningMessage, testkeeper.RemovedTestsDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains(testkeeper.WithTestsMsg),
+						HaveBodyThatContains("* `src/test/java/io/openshift/booster/HttpApplicationTest.java`")))).
 				Create()
 
-			event := prMock.CreateCommentEvent(SentByReviewer, "/run work-in-progress", "created")
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should not consider tests moved between modules as removed when configured to fail on removed tests", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(testsMovedToModule).
+				WithConfigFile(ConfigYml(billingModuleFailingOnRemovedTests)).
+				WithoutComments().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName)),
+					Comment(To(
+						HaveBodyThatContains("| `services/billing/` | 1 | 1 | :white_check_mark: tests exist |")))).
+				Create()
 
 			// when
-			err := handler.HandleIssueCommentEvent(log, event)
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
 
-			// then
+			// then - implicit verification of /statuses call occurrence with proper payload
 			Ω(err).ShouldNot(HaveOccurred())
 		})
-	})
-})
+
+		It("should approve pull request removing tests when configured to fail on removed tests, but bypass command is present", func() {
+			// given
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "bartoszmajsak")
+
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(removedTests).
+				WithUsers(Admin("bartoszmajsak")).
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("removed_tests", testkeeper.RemovedTestsFail)))).
+				WithComments(`[{"user":{"login":"bartoszmajsak"}, "body":"` + testkeeper.BypassCheckComment + `"}]`).
+				WithoutReviews().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should approve pull request when added tests satisfy configured ratio of test files to production files", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(smallTestForBigFeature).
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("min_test_files_ratio", "1")))).
+				WithoutComments().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should approve pull request without tests when production code change is below configured size threshold", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("skip_validation_below_lines", "10")))).
+				WithoutComments().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, testkeeper.OkSmallChangeMessage, testkeeper.OkSmallChangeDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should block pull request without tests when production code deletions exceed configured size threshold", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(`[{"filename":"src/main/java/io/openshift/booster/Greeting.java", "status":"modified", "additions":2, "deletions":40}]`).
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("skip_validation_below_lines", "10")))).
+				WithoutMessageFiles("test-keeper_without_tests_message.md").
+				WithoutComments().
+				WithoutReviews().
+				Expecting(
+					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName)),
+					Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should send ok status when PR contains no test but a comment with bypass command is present", func() {
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "bartoszmajsak")
+
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithUsers(Admin("bartoszmajsak")).
+				WithComments(`[{"user":{"login":"bartoszmajsak"}, "body":"` + testkeeper.BypassCheckComment + `"}]`).
+				WithoutReviews().
+				WithoutConfigFiles().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - should not expect any additional request mocking
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should send ok status when PR contains no test but a review with bypass command is present", func() {
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "bartoszmajsak")
+
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithUsers(Admin("bartoszmajsak")).
+				WithoutComments().
+				WithReviews(`[{"user":{"login":"bartoszmajsak"}, "state":"COMMENTED", "body":"` + testkeeper.BypassCheckComment + `\n\nDocs only"}]`).
+				WithoutConfigFiles().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - should not expect any additional request mocking
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should send ok status with the reason when PR contains no test but a comment with bypass command and the required reason is present", func() {
+			// given
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByWithReasonMessage, "bartoszmajsak", "docs-only refactor")
+
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithUsers(Admin("bartoszmajsak")).
+				WithComments(`[{"user":{"login":"bartoszmajsak"}, "body":"` + testkeeper.BypassCheckComment + ` because: docs-only refactor"}]`).
+				WithoutReviews().
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("require_bypass_reason", "true")))).
+				WithoutConfigFiles().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - should not expect any additional request mocking
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should block pull request without tests when the bypass command is present but the required reason is missing", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithComments(`[{"user":{"login":"bartoszmajsak"}, "body":"`+testkeeper.BypassCheckComment+`"}]`).
+				WithoutReviews().
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("require_bypass_reason", "true")))).
+				WithoutConfigFiles().
+				WithoutMessageFiles("test-keeper_without_tests_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName)),
+					Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should block pull request without tests when the bypass expired as new commits changed production code", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithUsers(Admin("bartoszmajsak")).
+				WithComments(`[{"user":{"login":"bartoszmajsak"}, "created_at":"2026-01-10T10:00:00Z", "body":"`+testkeeper.BypassCheckComment+`"}]`).
+				WithoutReviews().
+				WithCommits(bypassedCommits).
+				WithStatuses("df8e5cd15f05e1d975e17df322b9babedccf0a1a", verifiedAt("2026-01-11T10:00:00Z")).
+				WithStatuses("7a4d81ec7579fa508ef0abd97c5c5141b0fe5740", verifiedAt("2026-01-09T10:00:00Z")).
+				WithComparedFiles("7a4d81ec7579fa508ef0abd97c5c5141b0fe5740",
+					`[{"filename":"src/main/java/io/openshift/booster/Greeting.java", "status":"modified", "additions":120, "deletions":2}]`).
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("expire_bypass_on_new_commits", "true")))).
+				WithoutConfigFiles().
+				WithoutMessageFiles("test-keeper_without_tests_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, fmt.Sprintf(testkeeper.BypassExpiredMessage, "bartoszmajsak"), testkeeper.BypassExpiredDetailsPageName)),
+					Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should retrieve the commits, their statuses and the changes only once when several bypasses expired", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithUsers(Admin("bartoszmajsak"), Admin("matousjobanek")).
+				WithComments(`[{"user":{"login":"bartoszmajsak"}, "created_at":"2026-01-10T10:00:00Z", "body":"`+testkeeper.BypassCheckComment+`"},
+					{"user":{"login":"matousjobanek"}, "created_at":"2026-01-10T11:00:00Z", "body":"`+testkeeper.BypassCheckComment+`"}]`).
+				WithoutReviews().
+				WithCommits(bypassedCommits).
+				WithStatuses("df8e5cd15f05e1d975e17df322b9babedccf0a1a", verifiedAt("2026-01-11T10:00:00Z")).
+				WithStatuses("7a4d81ec7579fa508ef0abd97c5c5141b0fe5740", verifiedAt("2026-01-09T10:00:00Z")).
+				WithComparedFiles("7a4d81ec7579fa508ef0abd97c5c5141b0fe5740",
+					`[{"filename":"src/main/java/io/openshift/booster/Greeting.java", "status":"modified", "additions":120, "deletions":2}]`).
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("expire_bypass_on_new_commits", "true")))).
+				WithoutConfigFiles().
+				WithoutMessageFiles("test-keeper_without_tests_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, fmt.Sprintf(testkeeper.BypassExpiredMessage, "matousjobanek"), testkeeper.BypassExpiredDetailsPageName)),
+					Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+			// then - implicit verification that none of the requests is sent more than once
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should block pull request without tests when the bypass expired by new commits with backdated committer date", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithUsers(Admin("bartoszmajsak")).
+				WithComments(`[{"user":{"login":"bartoszmajsak"}, "created_at":"2026-01-10T10:00:00Z", "body":"`+testkeeper.BypassCheckComment+`"}]`).
+				WithoutReviews().
+				WithCommits(`[
+					{"sha":"7a4d81ec7579fa508ef0abd97c5c5141b0fe5740", "commit":{"committer":{"date":"2026-01-09T10:00:00Z"}}},
+					{"sha":"df8e5cd15f05e1d975e17df322b9babedccf0a1a", "commit":{"committer":{"date":"2026-01-09T11:00:00Z"}}}
+				]`).
+				WithStatuses("df8e5cd15f05e1d975e17df322b9babedccf0a1a", verifiedAt("2026-01-11T10:00:00Z")).
+				WithStatuses("7a4d81ec7579fa508ef0abd97c5c5141b0fe5740", verifiedAt("2026-01-09T10:00:00Z")).
+				WithComparedFiles("7a4d81ec7579fa508ef0abd97c5c5141b0fe5740",
+					`[{"filename":"src/main/java/io/openshift/booster/Greeting.java", "status":"modified", "additions":120, "deletions":2}]`).
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("expire_bypass_on_new_commits", "true")))).
+				WithoutConfigFiles().
+				WithoutMessageFiles("test-keeper_without_tests_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, fmt.Sprintf(testkeeper.BypassExpiredMessage, "bartoszmajsak"), testkeeper.BypassExpiredDetailsPageName)),
+					Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should block pull request without tests when the bypass expired as none of the commits existed when it was given", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithUsers(Admin("bartoszmajsak")).
+				WithComments(`[{"user":{"login":"bartoszmajsak"}, "created_at":"2026-01-10T10:00:00Z", "body":"`+testkeeper.BypassCheckComment+`"}]`).
+				WithoutReviews().
+				WithCommits(bypassedCommits).
+				WithStatuses("df8e5cd15f05e1d975e17df322b9babedccf0a1a", verifiedAt("2026-01-11T10:00:00Z")).
+				WithStatuses("7a4d81ec7579fa508ef0abd97c5c5141b0fe5740", verifiedAt("2026-01-11T09:00:00Z")).
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("expire_bypass_on_new_commits", "true")))).
+				WithoutConfigFiles().
+				WithoutMessageFiles("test-keeper_without_tests_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, fmt.Sprintf(testkeeper.BypassExpiredMessage, "bartoszmajsak"), testkeeper.BypassExpiredDetailsPageName)),
+					Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should keep the bypass given for the current head of the pull request", func() {
+			// given
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "bartoszmajsak")
+
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithUsers(Admin("bartoszmajsak")).
+				WithComments(`[{"user":{"login":"bartoszmajsak"}, "created_at":"2026-01-12T10:00:00Z", "body":"`+testkeeper.BypassCheckComment+`"}]`).
+				WithoutReviews().
+				WithCommits(bypassedCommits).
+				WithStatuses("df8e5cd15f05e1d975e17df322b9babedccf0a1a", verifiedAt("2026-01-11T10:00:00Z")).
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("expire_bypass_on_new_commits", "true")))).
+				WithoutConfigFiles().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+			// then - should not expect any additional request mocking
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should keep the bypass when new commits changed only files excluded from the test verification", func() {
+			// given
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "bartoszmajsak")
+
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithUsers(Admin("bartoszmajsak")).
+				WithComments(`[{"user":{"login":"bartoszmajsak"}, "created_at":"2026-01-10T10:00:00Z", "body":"`+testkeeper.BypassCheckComment+`"}]`).
+				WithoutReviews().
+				WithCommits(bypassedCommits).
+				WithStatuses("df8e5cd15f05e1d975e17df322b9babedccf0a1a", verifiedAt("2026-01-11T10:00:00Z")).
+				WithStatuses("7a4d81ec7579fa508ef0abd97c5c5141b0fe5740", verifiedAt("2026-01-09T10:00:00Z")).
+				WithComparedFiles("7a4d81ec7579fa508ef0abd97c5c5141b0fe5740",
+					`[{"filename":"README.adoc", "status":"modified", "additions":1, "deletions":1}]`).
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("expire_bypass_on_new_commits", "true")))).
+				WithoutConfigFiles().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+			// then - should not expect any additional request mocking
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should block pull request without tests and with comments containing bypass message added by user with insufficient permissions", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithUsers(ExternalUser("bartoszmajsak-test")).
+				WithComments(LoadedFrom("test_fixtures/github_calls/prs/comments_with_no_test_status_msg.json")).
+				// reviews are loaded both by the permission check of the user and when looking for the bypass
+				WithoutReviews(Times(2)).
+				WithoutConfigFiles().
+				WithoutMessageFiles("test-keeper_without_tests_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName))).
+				Create()
+
+			// when
+			err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("Pull Request label event handling", func() {
+
+		BeforeEach(func() {
+			defer gock.OffAll()
+			handler = &testkeeper.GitHubTestEventsHandler{Client: NewDefaultGitHubClient(), BotName: botName}
+		})
+
+		AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+		It("should send ok status when PR without tests is labeled with bypass label by admin user", func() {
+			// given
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByLabelMessage, "bartoszmajsak", "no-tests-needed")
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithLabels("no-tests-needed").
+				WithUsers(Admin("bartoszmajsak")).
+				WithIssueEvents(`[{"event":"labeled", "actor":{"login":"bartoszmajsak"}, "label":{"name":"no-tests-needed"}}]`).
+				WithoutReviews().
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("bypass_labels", "[no-tests-needed]")))).
+				WithoutConfigFiles().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			event := prMock.CreatePullRequestEvent("labeled")
+			event.Label = &gogh.Label{Name: gogh.String("no-tests-needed")}
+
+			// when
+			err := handler.HandlePullRequestEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should block PR without tests when bypass label is applied by user with insufficient permissions", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithLabels("no-tests-needed").
+				WithUsers(ExternalUser("bartoszmajsak-test")).
+				WithIssueEvents(`[{"event":"labeled", "actor":{"login":"bartoszmajsak-test"}, "label":{"name":"no-tests-needed"}}]`).
+				// reviews are loaded both by the permission check of the user and when looking for the bypass
+				WithoutReviews(Times(2)).
+				WithoutComments().
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("bypass_labels", "[no-tests-needed]")))).
+				WithoutConfigFiles().
+				WithoutMessageFiles("test-keeper_without_tests_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName)),
+					Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg))).
+				Create()
+
+			event := prMock.CreatePullRequestEvent("labeled")
+			event.Label = &gogh.Label{Name: gogh.String("no-tests-needed")}
+
+			// when
+			err := handler.HandlePullRequestEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should block PR without tests again when bypass label is removed", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithoutLabels().
+				WithoutComments().
+				WithoutReviews().
+				WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("bypass_labels", "[no-tests-needed]")))).
+				WithoutConfigFiles().
+				WithoutMessageFiles("test-keeper_without_tests_message.md").
+				Expecting(
+					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName)),
+					Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg))).
+				Create()
+
+			event := prMock.CreatePullRequestEvent("unlabeled")
+			event.Label = &gogh.Label{Name: gogh.String("no-tests-needed")}
+
+			// when
+			err := handler.HandlePullRequestEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should ignore PR labeled with a label which is not configured as bypass label", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithLabels("bug").
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("bypass_labels", "[no-tests-needed]")))).
+				Expecting(NoStatus()).
+				Create()
+
+			event := prMock.CreatePullRequestEvent("labeled")
+			event.Label = &gogh.Label{Name: gogh.String("bug")}
+
+			// when
+			err := handler.HandlePullRequestEvent(log, event)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should ignore PR labeled with a bypass label configured only in the pull request itself", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithLabels("no-tests-needed").
+				WithoutBaseConfigFiles().
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("bypass_labels", "[no-tests-needed]")))).
+				Expecting(NoStatus()).
+				Create()
+
+			event := prMock.CreatePullRequestEvent("labeled")
+			event.Label = &gogh.Label{Name: gogh.String("no-tests-needed")}
+
+			// when
+			err := handler.HandlePullRequestEvent(log, event)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("Pull Request comment event handling", func() {
+
+		BeforeEach(func() {
+			defer gock.OffAll()
+			handler = &testkeeper.GitHubTestEventsHandler{Client: NewDefaultGitHubClient(), BotName: botName}
+		})
+
+		AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+		It("should skip test existence check when "+testkeeper.BypassCheckComment+" command is used by admin user", func() {
+			// given
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "bartoszmajsak")
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithUsers(Admin("bartoszmajsak")).
+				WithoutReviews().
+				WithoutConfigFiles().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak"), testkeeper.BypassCheckComment, "created")
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should skip test existence check when "+testkeeper.BypassCheckComment+" command is used in a review submitted by admin user", func() {
+			// given
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "bartoszmajsak")
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithUsers(Admin("bartoszmajsak")).
+				WithoutReviews().
+				WithoutConfigFiles().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			event := prMock.CreateReviewEvent(SentBy("bartoszmajsak"), testkeeper.BypassCheckComment+"\n\nOnly documentation is changed", "submitted")
+
+			// when
+			err := handler.HandlePullRequestReviewEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should acknowledge "+testkeeper.BypassCheckComment+" command used in a review comment with reactions when configured", func() {
+			// given
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "bartoszmajsak")
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithUsers(Admin("bartoszmajsak")).
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("command_feedback", "reactions")))).
+				WithoutConfigFiles().
+				Expecting(
+					ReviewCommentReaction(2, "eyes"),
+					ReviewCommentReaction(2, "+1"),
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			event := prMock.CreateReviewCommentEvent(SentBy("bartoszmajsak"), testkeeper.BypassCheckComment, "created")
+			event.Comment.ID = gogh.Int64(2)
+
+			// when
+			err := handler.HandlePullRequestReviewCommentEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should acknowledge "+testkeeper.BypassCheckComment+" command used by admin user with reactions when configured", func() {
+			// given
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "bartoszmajsak")
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithUsers(Admin("bartoszmajsak")).
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("command_feedback", "reactions")))).
+				WithoutConfigFiles().
+				Expecting(
+					Reaction(1, "eyes"),
+					Reaction(1, "+1"),
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak"), testkeeper.BypassCheckComment, "created")
+			event.Comment.ID = gogh.Int64(1)
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should show the reason given in "+testkeeper.BypassCheckComment+" command in the status description", func() {
+			// given
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByWithReasonMessage, "bartoszmajsak", "only the build has been changed")
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithUsers(Admin("bartoszmajsak")).
+				WithoutReviews().
+				WithoutConfigFiles().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak"), testkeeper.BypassCheckComment+" because: only the build has been changed", "created")
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should reject "+testkeeper.BypassCheckComment+" command without the reason when it's required", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("require_bypass_reason", "true")))).
+				WithoutConfigFiles().
+				Expecting(
+					NoReaction(1),
+					NoComment(),
+					NoStatus()).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak"), testkeeper.BypassCheckComment, "created")
+			event.Comment.ID = gogh.Int64(1)
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should reject "+testkeeper.BypassCheckComment+" command with unexpected arguments", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithoutConfigFiles().
+				Expecting(
+					NoReaction(1),
+					NoComment(),
+					NoStatus()).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak"), testkeeper.BypassCheckComment+" because of docs", "created")
+			event.Comment.ID = gogh.Int64(1)
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should comment on "+testkeeper.BypassCheckComment+" command with unexpected arguments when comments are configured", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("command_feedback", "comments")))).
+				WithoutConfigFiles().
+				Expecting(
+					Comment(To(
+						HaveBodyThatContains("Hey @bartoszmajsak! It seems you tried to trigger `/ok-without-tests` command but it couldn't be processed"),
+						HaveBodyThatContains("`because of docs`"))),
+					NoReaction(1),
+					NoStatus()).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak"), testkeeper.BypassCheckComment+" because of docs", "created")
+			event.Comment.ID = gogh.Int64(1)
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should fall back to the default feedback when unknown command_feedback is configured", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("command_feedback", "comment")))).
+				WithoutConfigFiles().
+				Expecting(
+					NoReaction(1),
+					NoComment(),
+					NoStatus()).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak"), testkeeper.BypassCheckComment+" because of docs", "created")
+			event.Comment.ID = gogh.Int64(1)
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should ignore "+testkeeper.BypassCheckComment+" when used by non-admin user", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithUsers(ExternalUser("bartoszmajsak-test")).
+				WithoutReviews().
+				WithoutConfigFiles().
+				Expecting(
+					Comment(To(
+						HaveBodyThatContains("Hey @bartoszmajsak-test! It seems you tried to trigger `/ok-without-tests` command"),
+						HaveBodyThatContains("You have to be admin or requested reviewer or pull request approver, but not pull request creator"))),
+					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName))).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak-test"), testkeeper.BypassCheckComment, "created")
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+		It("should ignore command permissions configured in the pull request itself", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithUsers(ExternalUser("bartoszmajsak-test")).
+				WithoutReviews().
+				WithConfigFile(
+					ConfigYml(Containing(
+						Param("commands", "{ok-without-tests: {any_of: [anyone]}}")))).
+				Expecting(
+					Comment(To(
+						HaveBodyThatContains("Hey @bartoszmajsak-test! It seems you tried to trigger `/ok-without-tests` command"))),
+					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName))).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak-test"), testkeeper.BypassCheckComment, "created")
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+		It("should skip test existence check when "+testkeeper.BypassCheckComment+" command is used by user allowed in configured command permissions", func() {
+			// given
+			approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "bartoszmajsak-test")
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithUsers(Collaborator("bartoszmajsak-test", "write")).
+				WithConfigFile(
+					BaseConfigYml(Containing(
+						Param("commands", "{ok-without-tests: {any_of: ['permission:write']}}")))).
+				WithoutConfigFiles().
+				Expecting(
+					Status(ToBe(github.StatusSuccess, approvedBy, testkeeper.ApprovedByDetailsPageName))).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak-test"), testkeeper.BypassCheckComment, "created")
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+		It("should explain categories of changed files when "+testkeeper.ExplainComment+" command is used", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithoutConfigFiles().
+				Expecting(
+					Comment(To(
+						HaveBodyThatContains("Hey @bartoszmajsak-test! This is how `test-keeper` has categorized"),
+						HaveBodyThatContains("| `Randomfile` | production |  |  |"),
+						HaveBodyThatContains("| `README.adoc` | skipped | `*.adoc` |"))),
+					NoStatus()).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak-test"), testkeeper.ExplainComment, "created")
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then - implicit verification of /comments call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should escape pipes in the names of the files explained by "+testkeeper.ExplainComment+" command", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(`[{"filename":"src/main/java/a|b.java", "status":"added", "additions":20, "deletions":0}]`).
+				WithoutConfigFiles().
+				Expecting(
+					Comment(To(
+						HaveBodyThatContains("| `src/main/java/a\\|b.java` | production |  |  |"))),
+					NoStatus()).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak-test"), testkeeper.ExplainComment, "created")
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then - implicit verification of /comments call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should list only limited number of changed files when "+testkeeper.ExplainComment+" command is used", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(changedFiles(260, "README.adoc")).
+				WithoutConfigFiles().
+				Expecting(
+					Comment(To(
+						HaveBodyThatContains("| `src/main/java/io/openshift/booster/Generated249.java` | production |  |  |"),
+						HaveBodyThatContains("_... and 10 more files_"))),
+					NoStatus()).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak-test"), testkeeper.ExplainComment, "created")
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then - implicit verification of /comments call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("Trigger test-keeper plugin by triggering comment on pull request", func() {
+		BeforeEach(func() {
+			defer gock.OffAll()
+			handler = &testkeeper.GitHubTestEventsHandler{Client: NewDefaultGitHubClient(), BotName: botName}
+		})
+
+		AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+		It("should block newly created pull request without tests when "+command.RunCommentPrefix+" all command is used by admin user", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
+				WithoutComments().
+				WithoutReviews().
+				WithUsers(Admin("bartoszmajsak")).
+				WithoutConfigFiles().
+				WithoutMessageFiles("test-keeper_without_tests_message.md").
+				Expecting(Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg)),
+					Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName))).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentBy("bartoszmajsak"), "/run all", "created")
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should approve newly created pull request with tests when "+command.RunCommentPrefix+" "+testkeeper.ProwPluginName+" command is triggered by pr reviewer", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/with_tests/changes.json")).
+				WithoutComments().
+				WithoutReviews().
+				WithUsers(ExternalUser("bartoszmajsak-test"), RequestedReviewer("bartoszmajsak-test")).
+				WithoutConfigFiles().
+				Expecting(Comment(ContainingStatusMessage(testkeeper.WithoutTestsMsg)),
+					Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName))).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentByReviewer, "/run test-keeper", "created")
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then - implicit verification of /statuses call occurrence with proper payload
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should do nothing for newly created pull request with tests when "+command.RunCommentPrefix+" work-in-progress command is triggered by pr reviewer", func() {
+			// given
+			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithFiles(LoadedFrom("test_fixtures/github_calls/prs/with_tests/changes.json")).
+				WithoutComments().
+				WithoutReviews().
+				WithUsers(ExternalUser("bartoszmajsak-test"), RequestedReviewer("bartoszmajsak-test")).
+				WithoutConfigFiles().
+				Expecting(
+					NoStatus(),
+					NoComment()).
+				Create()
+
+			event := prMock.CreateCommentEvent(SentByReviewer, "/run work-in-progress", "created")
+
+			// when
+			err := handler.HandleIssueCommentEvent(log, event)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+})
+
+const bypassedCommits = `[
+	{"sha":"7a4d81ec7579fa508ef0abd97c5c5141b0fe5740", "commit":{"committer":{"date":"2026-01-09T10:00:00Z"}}},
+	{"sha":"df8e5cd15f05e1d975e17df322b9babedccf0a1a", "commit":{"committer":{"date":"2026-01-11T10:00:00Z"}}}
+]`
+
+func verifiedAt(time string) string {
+	return `[
+	{"context":"` + botName + `/` + testkeeper.ProwPluginName + `", "state":"failure", "created_at":"` + time + `"},
+	{"context":"ci/jenkins", "state":"success", "created_at":"2025-01-01T10:00:00Z"}
+]`
+}
+
+const smallTestForBigFeature = `[
+	{"filename":"src/main/java/io/openshift/booster/Greeting.java", "status":"added", "additions":200, "deletions":0},
+	{"filename":"src/test/java/io/openshift/booster/GreetingTest.java", "status":"modified", "additions":5, "deletions":1}
+]`
+
+const partiallyPairedTests = `[
+	{"filename":"src/main/java/io/openshift/booster/Greeting.java", "status":"modified", "additions":20, "deletions":2},
+	{"filename":"src/main/java/io/openshift/booster/HttpApplication.java", "status":"modified", "additions":5, "deletions":1},
+	{"filename":"src/test/java/io/openshift/booster/HttpApplicationTest.java", "status":"modified", "additions":15, "deletions":0}
+]`
+
+const pairingRules = `test_pairs:
+  - source: src/main/java/**/*.java
+    tests:
+      - src/test/java/**/{name}Test.java
+`
+
+const monorepoChanges = `[
+	{"filename":"services/billing/src/main/java/com/acme/Invoice.java", "status":"modified", "additions":60, "deletions":4},
+	{"filename":"services/billing/src/test/java/com/acme/InvoiceSpec.java", "status":"modified", "additions":10, "deletions":0},
+	{"filename":"web/src/cart.ts", "status":"modified", "additions":12, "deletions":3},
+	{"filename":"web/src/cart.spec.ts", "status":"modified", "additions":8, "deletions":1},
+	{"filename":"README.adoc", "status":"modified", "additions":2, "deletions":1}
+]`
+
+const strictBillingModule = `modules:
+  services/billing/:
+    test_patterns: ['**/*Spec.java']
+    min_test_lines_ratio: 0.5
+  web/:
+    test_patterns: ['**/*.spec.ts']
+    inherit_patterns: false
+`
+
+const lenientBillingModule = `modules:
+  services/billing/:
+    test_patterns: ['**/*Spec.java']
+    min_test_lines_ratio: 0.1
+  web/:
+    test_patterns: ['**/*.spec.ts']
+`
+
+const billingModuleFailingOnRemovedTests = `removed_tests: fail
+modules:
+  services/billing/:
+    test_patterns: ['**/*Spec.java']
+`
+
+const testsMovedToModule = `[
+	{"filename":"services/billing/src/main/java/io/openshift/booster/Invoice.java", "status":"added", "additions":40, "deletions":0},
+	{"filename":"services/billing/src/test/java/io/openshift/booster/InvoiceSpec.java", "status":"added", "additions":60, "deletions":0},
+	{"filename":"src/test/java/io/openshift/booster/InvoiceSpec.java", "status":"removed", "additions":0, "deletions":60},
+	{"filename":"src/test/java/io/openshift/booster/GreetingTest.java", "status":"modified", "additions":5, "deletions":1}
+]`
+
+const testChangedWithoutNewCases = `[
+	{"filename":"src/main/java/io/openshift/booster/Greeting.java", "status":"modified", "additions":12, "deletions":2,
+		"patch":"@@ -20,6 +20,16 @@\n public class Greeting {\n+    private final String name;\n }"},
+	{"filename":"src/test/java/io/openshift/booster/GreetingTest.java", "status":"renamed", "additions":1, "deletions":1,
+		"patch":"@@ -1,3 +1,3 @@\n-package io.openshift;\n+package io.openshift.booster;\n \n     @Test"}
+]`
+
+const removedTests = `[
+	{"filename":"src/main/java/io/openshift/booster/Greeting.java", "status":"modified", "additions":12, "deletions":2},
+	{"filename":"src/test/java/io/openshift/booster/GreetingTest.java", "status":"modified", "additions":3, "deletions":20},
+	{"filename":"src/test/java/io/openshift/booster/HttpApplicationTest.java", "status":"removed", "additions":0, "deletions":66}
+]`
+
+const generatedCode = `[
+	{"filename":"pkg/greeting/greeting.pb.go", "status":"modified", "additions":120, "deletions":80},
+	{"filename":"api/openapi/client.go", "status":"modified", "additions":40, "deletions":10}
+]`
+
+// changedFiles creates a payload of the given number of changed files - production ones followed by the last file
+func changedFiles(count int, last string) string {
+	files := make([]string, count)
+	for i := 0; i < count-1; i++ {
+		files[i] = fmt.Sprintf(`{"filename":"src/main/java/io/openshift/booster/Generated%d.java", "status":"added", "additions":10, "deletions":0}`, i)
+	}
+	files[count-1] = fmt.Sprintf(`{"filename":"%s", "status":"modified", "additions":5, "deletions":1}`, last)
+	return "[" + strings.Join(files, ",") + "]"
+}
@@ -0,0 +1,101 @@
+package testkeeper
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// TestCategory is a category of files matching any of the inclusion patterns
+	TestCategory = "test"
+	// SkippedCategory is a category of files matching any of the exclusion patterns
+	SkippedCategory = "skipped"
+	// ProductionCategory is a category of files matching neither the inclusion nor the exclusion patterns
+	ProductionCategory = "production"
+)
+
+const (
+	// explainedFilesLimit is the maximal number of files listed in the reply to the explain command, so the comment
+	// doesn't exceed the size accepted by GitHub
+	explainedFilesLimit = 250
+	// detailedFilesLimit is the maximal number of files listed in the collapsible section of the status message
+	detailedFilesLimit = 50
+)
+
+// FileExplanation holds the category a file has been assigned to together with the pattern that decided about it.
+// The Pattern is nil for production files as they don't match any pattern
+type FileExplanation struct {
+	File     string
+	Category string
+	Pattern  *FilePattern
+}
+
+// Explain finds the category of the given file the same way FileCategoryCounter does - exclusions take precedence
+// over inclusions - and returns it together with the pattern that decided about the category
+func (matcher *TestMatcher) Explain(filename string) FileExplanation {
+	exclusions, inclusions := FilePatterns(matcher.Exclusion), FilePatterns(matcher.Inclusion)
+	if pattern := exclusions.Decisive(filename); pattern != nil {
+		return FileExplanation{File: filename, Category: SkippedCategory, Pattern: pattern}
+	}
+	if pattern := matcher.Attributes.SkippedBy(filename); pattern != nil {
+		return FileExplanation{File: filename, Category: SkippedCategory, Pattern: pattern}
+	}
+	if pattern := inclusions.Decisive(filename); pattern != nil {
+		return FileExplanation{File: filename, Category: TestCategory, Pattern: pattern}
+	}
+	return FileExplanation{File: filename, Category: ProductionCategory}
+}
+
+// explanation creates a markdown table of the changed files with their categories and the patterns they are matched by.
+// When there are modules configured, then the table contains also the module each of the files belongs to. At most
+// limit of the files is listed, the number of the omitted ones is stated below the table
+func (checks moduleChecks) explanation(limit int) string {
+	withModules := !(len(checks) == 1 && checks[0].path == "")
+
+	var table strings.Builder
+	if withModules {
+		table.WriteString("| File | Module | Category | Pattern | Regexp |\n|---|---|---|---|---|\n")
+	} else {
+		table.WriteString("| File | Category | Pattern | Regexp |\n|---|---|---|---|\n")
+	}
+	listed, omitted := 0, 0
+	for _, check := range checks {
+		if check.categories.Files == nil {
+			continue
+		}
+		for _, file := range *check.categories.Files {
+			if listed == limit {
+				omitted++
+				continue
+			}
+			listed++
+			explained := check.matcher.Explain(file.Name)
+			if inline := inlineTestsSignature(check.matcher.InlineTests, file); explained.Category == ProductionCategory && inline != nil {
+				explained = FileExplanation{File: file.Name, Category: TestCategory, Pattern: inline}
+			}
+			pattern, expr := "", ""
+			if explained.Pattern != nil {
+				pattern, expr = inlineCode(explained.Pattern.Pattern), inlineCode(explained.Pattern.Regexp)
+			}
+			if withModules {
+				table.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", inlineCode(file.Name), check.name(), explained.Category, pattern, expr))
+			} else {
+				table.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", inlineCode(file.Name), explained.Category, pattern, expr))
+			}
+		}
+	}
+	if omitted > 0 {
+		table.WriteString(fmt.Sprintf("\n_... and %d more files_\n", omitted))
+	}
+	return strings.TrimSpace(table.String())
+}
+
+// explanationDetails wraps the explanation table in a collapsible section so it doesn't clutter the status message
+func (checks moduleChecks) explanationDetails() string {
+	return "<details>\n<summary>How the changed files have been categorized</summary>\n\n" + checks.explanation(detailedFilesLimit) + "\n\n</details>"
+}
+
+// inlineCode formats the given text as markdown inline code which can be used in a table cell
+func inlineCode(text string) string {
+	return "`" + strings.Replace(text, "|", "\\|", -1) + "`"
+}
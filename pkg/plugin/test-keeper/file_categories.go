@@ -8,15 +8,33 @@ import (
 
 // FileCategoryCounter is using plugin.FilePattern to figure out if the given commit affects any test file
 // The plugin.FilePattern is loaded either from test-keeper.yaml file or from set of default matchers based on the languages using in the related project
+// When PairingRules are set, it also checks that every changed production file is accompanied by its paired test.
+// When RequireNewTestCases is set, only the test files which add new test cases are counted as tests
 type FileCategoryCounter struct {
-	Matcher TestMatcher
+	Matcher             TestMatcher
+	PairingRules        []TestPairingRule
+	RequireNewTestCases bool
 }
 
-// FileCategories holds information about the total files coming in the changeset, skipped files (those which are excluded from test verification)
-// and tests
+// FileCategories holds information about the total files coming in the changeset, skipped files (those which are excluded from test verification),
+// tests and production files together with the number of lines added to the tests and to the production code (and
+// deleted from the production code).
+// MissingTests lists production files which are not accompanied by the paired tests, TestsWithoutNewCases lists
+// test files which are changed without adding any new test case (only when the new test cases are required),
+// ShrunkTests lists test files which are removed or have more deleted than added lines and RemovedTests lists them only
+// when the changeset removes more test lines than it adds. TestLinesAdded and TestLinesDeleted count the lines of all
+// the test files, so the removed tests can be also decided across several changesets (e.g. modules)
 type FileCategories struct {
-	Total, Skipped, Tests int
-	Files                 *[]scm.ChangedFile
+	Total, Skipped, Tests, Production  int
+	TestAdditions, ProductionAdditions int
+	ProductionDeletions                int
+	TestLinesAdded, TestLinesDeleted   int
+	TestCases                          int
+	MissingTests                       []MissingTest
+	TestsWithoutNewCases               []string
+	ShrunkTests                        []string
+	RemovedTests                       []string
+	Files                              *[]scm.ChangedFile
 }
 
 // OnlySkippedFiles indicates if changeset contains only files which are excluded from test verification
@@ -29,53 +47,118 @@ func (f *FileCategories) TestsExist() bool {
 	return f.Tests > 0
 }
 
+// AllTestsPaired answers if all changed production files are accompanied by the tests required by the pairing rules
+func (f *FileCategories) AllTestsPaired() bool {
+	return len(f.MissingTests) == 0
+}
+
 // NewFileTypes creates new instance of FileCategories struct with files populated
 func NewFileTypes(files []scm.ChangedFile) FileCategories {
 	return FileCategories{Files: &files, Total: len(files)}
 }
 
-// Count counts files in the changeset which are tests (included files) and should not be considered for
-// verification (excluded). When first test is found it stops, as this is enough to unblock PR
+// Count counts files in the changeset which are tests (included files or files adding inline tests), production files
+// (neither included nor excluded) and should not be considered for verification (excluded). Tests which are only removed or have only deletions are not counted.
+// Production files which are not removed are checked against the pairing rules. Removed or shrunk tests are collected
+// when the changeset removes more test lines than it adds
 func (t *FileCategoryCounter) Count(files []scm.ChangedFile) (FileCategories, error) {
 	types := NewFileTypes(files)
+	var productionFiles, changedFiles []string
 	for _, file := range files {
 		if file.Name == "" {
 			return types, errors.New("can't have empty file name")
 		}
+		onlyDeletions := file.Additions == 0 && file.Deletions > 0
+		changed := !(file.Status == "removed" || onlyDeletions)
+		if changed {
+			changedFiles = append(changedFiles, file.Name)
+		}
 		excluded := t.Matcher.MatchesExclusion(file.Name)
 		if !excluded {
 			if t.Matcher.MatchesInclusion(file.Name) {
-				onlyDeletions := file.Additions == 0 && file.Deletions > 0
-				if !(file.Status == "removed" || onlyDeletions) {
+				types.TestLinesAdded += file.Additions
+				types.TestLinesDeleted += file.Deletions
+				if file.Status == "removed" || file.Deletions > file.Additions {
+					types.ShrunkTests = append(types.ShrunkTests, file.Name)
+				}
+				testCases, verifiable := t.newTestCases(file)
+				types.TestCases += testCases
+				switch {
+				case !changed:
+				case t.RequireNewTestCases && verifiable && testCases == 0:
+					types.TestsWithoutNewCases = append(types.TestsWithoutNewCases, file.Name)
+				default:
 					types.Tests++
-					return types, nil // As we found the first test and we don't care about the amount of them, we can return
+					types.TestAdditions += file.Additions
+				}
+			} else if changed && inlineTestsSignature(t.Matcher.InlineTests, file) != nil {
+				// production file adding tests written inline (e.g. #[cfg(test)] module in Rust) is considered as a test
+				types.Tests++
+				types.TestAdditions += file.Additions
+			} else {
+				types.Production++
+				types.ProductionAdditions += file.Additions
+				types.ProductionDeletions += file.Deletions
+				if file.Status != "removed" {
+					productionFiles = append(productionFiles, file.Name)
 				}
 			}
 		} else {
 			types.Skipped++
 		}
 	}
+
+	if types.TestLinesDeleted > types.TestLinesAdded {
+		types.RemovedTests = types.ShrunkTests
+	}
+
+	if len(t.PairingRules) > 0 {
+		pairings := parseTestPairingRules(t.PairingRules)
+		for _, production := range productionFiles {
+			if missing := missingTest(pairings, production, changedFiles); missing != nil {
+				types.MissingTests = append(types.MissingTests, *missing)
+			}
+		}
+	}
 	return types, nil
 }
 
-// LoadMatcher loads list of FilePattern either from the provided configuration or from languages retrieved from the given function
+// newTestCases counts the test cases added by the patch of the given file. A file without any added line (e.g. a pure
+// rename) adds no test case. The number can't be verified when the patch is not available or when there are no test
+// case signatures defined for the file
+func (t *FileCategoryCounter) newTestCases(file scm.ChangedFile) (count int, verifiable bool) {
+	signatures := signaturesFor(t.Matcher.TestCases, file.Name)
+	if len(signatures) == 0 {
+		return 0, false
+	}
+	if file.Additions == 0 {
+		return 0, true
+	}
+	if file.Patch == "" {
+		return 0, false
+	}
+	return countAddedTestCases(file.Patch, signatures), true
+}
+
+// LoadMatcher loads list of FilePattern either from the provided configuration or from the default patterns of the configured languages
 func LoadMatcher(configuration *PluginConfiguration) (TestMatcher, error) {
-	matcher, err := LoadDefaultMatcher()
+	matcher, err := LoadDefaultMatcherForLanguages(configuration.Languages)
 	if err != nil {
 		return matcher, err
 	}
 
 	if len(configuration.Inclusions) != 0 {
-		exclusions := ParseFilePatterns(configuration.Inclusions)
+		inclusions := ParseFilePatternsWithSyntax(configuration.Inclusions, configuration.patternSyntax())
 		if configuration.Combine {
-			matcher.Inclusion = append(matcher.Inclusion, exclusions...)
+			matcher.Inclusion = append(matcher.Inclusion, inclusions...)
 		} else {
-			matcher.Inclusion = exclusions
+			matcher.Inclusion = inclusions
+			matcher.InlineTests = nil
 		}
 	}
 
 	if len(configuration.Exclusions) != 0 {
-		exclusions := ParseFilePatterns(configuration.Exclusions)
+		exclusions := ParseFilePatternsWithSyntax(configuration.Exclusions, configuration.patternSyntax())
 		if configuration.Combine {
 			matcher.Exclusion = append(matcher.Exclusion, exclusions...)
 		} else {
@@ -83,5 +166,17 @@ func LoadMatcher(configuration *PluginConfiguration) (TestMatcher, error) {
 		}
 	}
 
+	if len(configuration.TestCasePatterns) != 0 {
+		testCases, err := ParseTestCaseSignatures(configuration.TestCasePatterns)
+		if err != nil {
+			return matcher, err
+		}
+		if configuration.Combine {
+			matcher.TestCases = append(matcher.TestCases, testCases...)
+		} else {
+			matcher.TestCases = testCases
+		}
+	}
+
 	return matcher, nil
 }
@@ -297,6 +297,356 @@ var _ = Describe("Test fileCategoryCounter features", func() {
 
 	})
 
+	Context("Counting tests and production code within file changeset", func() {
+
+		It("should count added lines of all test and production files", func() {
+			// given
+			changedFiles := []scm.ChangedFile{
+				{Name: "src/main/java/com/acme/Service.java", Status: "added", Additions: 120},
+				{Name: "src/main/java/com/acme/Repository.java", Status: "modified", Additions: 30, Deletions: 10},
+				{Name: "src/test/java/com/acme/ServiceTest.java", Status: "added", Additions: 40},
+				{Name: "src/test/java/com/acme/RepositoryTest.java", Status: "removed", Deletions: 25},
+				{Name: "README.adoc", Status: "modified", Additions: 5, Deletions: 1},
+			}
+
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.Tests).To(Equal(1))
+			Expect(fileCategories.TestAdditions).To(Equal(40))
+			Expect(fileCategories.Production).To(Equal(2))
+			Expect(fileCategories.ProductionAdditions).To(Equal(150))
+			Expect(fileCategories.ProductionDeletions).To(Equal(10))
+			Expect(fileCategories.Skipped).To(Equal(1))
+		})
+	})
+
+	Context("Detecting new test cases within file changeset", func() {
+
+		It("should count only test files adding new test cases when they are required", func() {
+			// given
+			changedFiles := []scm.ChangedFile{
+				{Name: "src/main/java/com/acme/Service.java", Status: "modified", Additions: 10,
+					Patch: "@@ -1,3 +1,4 @@\n public class Service {\n+    private final Repository repository;\n }"},
+				{Name: "src/test/java/com/acme/ServiceTest.java", Status: "modified", Additions: 5, Deletions: 1,
+					Patch: "@@ -10,6 +10,10 @@\n-    // checks the service\n+    // verifies the service\n+    @Test\n+    public void should_call_repository() {\n+        service.call();\n+    }"},
+				{Name: "src/test/java/com/acme/RepositoryTest.java", Status: "modified", Additions: 1, Deletions: 1,
+					Patch: "@@ -1,4 +1,4 @@\n-// Copyright 2017\n+// Copyright 2018\n     @Test\n     public void should_store() {"},
+			}
+
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher, RequireNewTestCases: true}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.Tests).To(Equal(1))
+			Expect(fileCategories.TestCases).To(Equal(1))
+			Expect(fileCategories.TestsWithoutNewCases).To(ConsistOf("src/test/java/com/acme/RepositoryTest.java"))
+		})
+
+		It("should count test file without new test cases when they are not required", func() {
+			// given
+			changedFiles := []scm.ChangedFile{
+				{Name: "pkg/server/server_test.go", Status: "modified", Additions: 1, Deletions: 1,
+					Patch: "@@ -1,3 +1,3 @@\n-package server\n+package server_test"},
+			}
+
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.TestsExist()).To(BeTrue())
+			Expect(fileCategories.TestsWithoutNewCases).To(BeEmpty())
+		})
+
+		It("should count test file when new test cases can't be verified as the patch is missing", func() {
+			// given
+			changedFiles := []scm.ChangedFile{
+				{Name: "path/to/huge_test.go", Status: "modified", Additions: 4000, Deletions: 10},
+			}
+
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher, RequireNewTestCases: true}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.TestsExist()).To(BeTrue())
+		})
+
+		It("should not count renamed test file without any added line when new test cases are required", func() {
+			// given
+			changedFiles := []scm.ChangedFile{
+				{Name: "src/test/java/com/acme/RenamedServiceTest.java", Status: "renamed", Additions: 0, Deletions: 0},
+			}
+
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher, RequireNewTestCases: true}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.TestsExist()).To(BeFalse())
+			Expect(fileCategories.TestsWithoutNewCases).To(ConsistOf("src/test/java/com/acme/RenamedServiceTest.java"))
+		})
+
+		DescribeTable("should detect new test cases using predefined language signatures",
+			func(file, addedLine string) {
+				// given
+				changedFiles := []scm.ChangedFile{
+					{Name: file, Status: "modified", Additions: 1, Patch: "@@ -1,1 +1,2 @@\n context\n+" + addedLine},
+				}
+				fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher, RequireNewTestCases: true}
+
+				// when
+				fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+				// then
+				Ω(err).ShouldNot(HaveOccurred())
+				Expect(fileCategories.TestCases).To(Equal(1))
+			},
+			Entry("JUnit", "src/test/java/MyTest.java", "    @Test"),
+			Entry("JUnit 5 parameterized", "src/test/java/MyTest.java", "    @ParameterizedTest"),
+			Entry("Go", "pkg/my_test.go", "func TestSomething(t *testing.T) {"),
+			Entry("Ginkgo", "pkg/my_test.go", `		It("should work", func() {`),
+			Entry("Jest", "web/cart.spec.ts", `  test("adds item", () => {`),
+			Entry("Mocha", "web/cart.test.js", `  it.only('adds item', function() {`),
+			Entry("pytest", "tests/test_cart.py", "def test_adds_item(cart):"),
+			Entry("Spock", "src/test/groovy/CartTest.groovy", `    def "should add item"() {`),
+			Entry("Kotlin", "src/test/kotlin/CartTest.kt", "    @Test"),
+			Entry("ScalaTest", "src/test/scala/CartSpec.scala", `  "A cart" should "add item" in {`),
+			Entry("Rust", "tests/cart.rs", "#[tokio::test]"),
+			Entry("xUnit", "tests/Acme.Tests/CartTests.cs", "    [Fact]"),
+			Entry("RSpec", "spec/cart_spec.rb", "  it 'adds item' do"),
+			Entry("PHPUnit", "tests/CartTest.php", "    public function testAddsItem(): void"),
+			Entry("XCTest", "Tests/CartTests/CartTests.swift", "    func testAddsItem() throws {"),
+			Entry("GoogleTest", "src/cart_test.cpp", "TEST_F(CartTest, AddsItem) {"),
+			Entry("ExUnit", "test/cart_test.exs", `  test "adds item" do`),
+		)
+
+		It("should detect new test cases using configured signatures", func() {
+			// given
+			matcher, loaderErr := testkeeper.LoadMatcher(&testkeeper.PluginConfiguration{
+				Inclusions:       []string{"*_spec.rb"},
+				TestCasePatterns: map[string][]string{"*_spec.rb": {`^\s*it\s+["']`}},
+				Combine:          true,
+			})
+			changedFiles := []scm.ChangedFile{
+				{Name: "spec/cart_spec.rb", Status: "modified", Additions: 3,
+					Patch: "@@ -1,1 +1,4 @@\n describe Cart do\n+  it 'adds item' do\n+    expect(cart.items).to be_empty\n+  end"},
+			}
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: matcher, RequireNewTestCases: true}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(loaderErr).ShouldNot(HaveOccurred())
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.TestCases).To(Equal(1))
+		})
+
+		It("should fail loading matcher when configured signature is not a valid regexp", func() {
+			// given
+			configuration := &testkeeper.PluginConfiguration{
+				TestCasePatterns: map[string][]string{"*_spec.rb": {`it(`}},
+			}
+
+			// when
+			_, err := testkeeper.LoadMatcher(configuration)
+
+			// then
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("Detecting inline tests within file changeset", func() {
+
+		It("should count production file adding a test module as a test", func() {
+			// given
+			changedFiles := []scm.ChangedFile{
+				{Name: "src/cart.rs", Status: "modified", Additions: 8,
+					Patch: "@@ -10,1 +10,9 @@\n }\n+\n+#[cfg(test)]\n+mod tests {\n+    use super::*;\n+\n+    #[test]\n+    fn adds_item() {}\n+}"},
+				{Name: "src/price.rs", Status: "modified", Additions: 4,
+					Patch: "@@ -1,1 +1,5 @@\n pub fn price() {\n+    let total = 0;\n+    total\n+}\n+"},
+			}
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.Tests).To(Equal(1))
+			Expect(fileCategories.TestAdditions).To(Equal(8))
+			Expect(fileCategories.Production).To(Equal(1))
+		})
+
+		It("should not count production file adding a test module as a test when default patterns are replaced", func() {
+			// given
+			matcher, loaderErr := testkeeper.LoadMatcher(&testkeeper.PluginConfiguration{
+				Inclusions: []string{"tests/"},
+			})
+			changedFiles := []scm.ChangedFile{
+				{Name: "src/cart.rs", Status: "modified", Additions: 2,
+					Patch: "@@ -10,1 +10,3 @@\n }\n+#[test]\n+fn adds_item() {}"},
+			}
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: matcher}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(loaderErr).ShouldNot(HaveOccurred())
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.TestsExist()).To(BeFalse())
+		})
+	})
+
+	Context("Detecting removed tests within file changeset", func() {
+
+		It("should list removed and shrunk tests when more test lines are removed than added", func() {
+			// given
+			changedFiles := []scm.ChangedFile{
+				{Name: "src/main/java/com/acme/Service.java", Status: "modified", Additions: 10, Deletions: 40},
+				{Name: "src/test/java/com/acme/ServiceTest.java", Status: "modified", Additions: 2, Deletions: 15},
+				{Name: "src/test/java/com/acme/LegacyTest.java", Status: "removed", Deletions: 80},
+				{Name: "src/test/java/com/acme/ClientTest.java", Status: "modified", Additions: 12, Deletions: 1},
+			}
+
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.RemovedTests).To(ConsistOf(
+				"src/test/java/com/acme/ServiceTest.java", "src/test/java/com/acme/LegacyTest.java"))
+		})
+
+		It("should not list removed tests when they are replaced by equivalent ones", func() {
+			// given
+			changedFiles := []scm.ChangedFile{
+				{Name: "src/test/java/com/acme/LegacyTest.java", Status: "removed", Deletions: 80},
+				{Name: "src/test/java/com/acme/ModernTest.java", Status: "added", Additions: 95},
+			}
+
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.RemovedTests).To(BeEmpty())
+		})
+	})
+
+	Context("Pairing production files with tests within file changeset", func() {
+
+		javaPairing := []testkeeper.TestPairingRule{
+			{Source: "src/main/java/**/*.java", Tests: []string{"src/test/java/**/{name}Test.java", "src/test/java/**/{name}IT.java"}},
+		}
+		goPairing := []testkeeper.TestPairingRule{
+			{Source: "**/*.go", Tests: []string{"{dir}/{name}_test.go"}},
+		}
+
+		It("should list production files without paired tests", func() {
+			// given
+			changedFiles := changedFilesSet(
+				"src/main/java/com/acme/Service.java",
+				"src/main/java/com/acme/Repository.java",
+				"src/main/java/com/acme/Client.java",
+				"src/test/java/com/acme/ServiceTest.java",
+				"src/test/java/com/acme/integration/ClientIT.java",
+				"src/test/java/com/acme/MockRepositoryTest.java")
+
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher, PairingRules: javaPairing}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.AllTestsPaired()).To(BeFalse())
+			Expect(fileCategories.MissingTests).To(ConsistOf(testkeeper.MissingTest{
+				File:     "src/main/java/com/acme/Repository.java",
+				Expected: []string{"src/test/java/**/RepositoryTest.java", "src/test/java/**/RepositoryIT.java"},
+			}))
+		})
+
+		It("should pair go files with tests in the same directory", func() {
+			// given
+			changedFiles := changedFilesSet(
+				"main.go",
+				"main_test.go",
+				"pkg/server/server.go",
+				"pkg/server/handler.go",
+				"pkg/server/handler_test.go",
+				"pkg/client/server_test.go")
+
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher, PairingRules: goPairing}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.MissingTests).To(ConsistOf(testkeeper.MissingTest{
+				File:     "pkg/server/server.go",
+				Expected: []string{"pkg/server/server_test.go"},
+			}))
+		})
+
+		It("should not require paired tests for removed production files and files not matching any rule", func() {
+			// given
+			changedFiles := []scm.ChangedFile{
+				{Name: "src/main/java/com/acme/Legacy.java", Status: "removed", Deletions: 80},
+				{Name: "src/main/resources/application.properties", Status: "modified", Additions: 2},
+				{Name: "src/main/java/com/acme/Service.java", Status: "modified", Additions: 10},
+				{Name: "src/test/java/com/acme/ServiceTest.java", Status: "modified", Additions: 12, Deletions: 3},
+			}
+
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher, PairingRules: javaPairing}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.AllTestsPaired()).To(BeTrue())
+		})
+
+		It("should not consider removed test as paired one", func() {
+			// given
+			changedFiles := []scm.ChangedFile{
+				{Name: "src/main/java/com/acme/Service.java", Status: "modified", Additions: 10},
+				{Name: "src/test/java/com/acme/ServiceTest.java", Status: "removed", Deletions: 30},
+			}
+
+			fileCategoryCounter := testkeeper.FileCategoryCounter{Matcher: defaultMatcher, PairingRules: javaPairing}
+
+			// when
+			fileCategories, err := fileCategoryCounter.Count(changedFiles)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(fileCategories.MissingTests).To(HaveLen(1))
+		})
+	})
+
 })
 
 func changedFilesSet(names ...string) []scm.ChangedFile {
@@ -8,25 +8,44 @@ import (
 const (
 	regexpDefinitionPrefix = "regex{{"
 	regexpDefinitionSuffix = "}}"
+	negationPrefix         = "!"
 	anyPathWildcard        = "**"
 	anyNameWildcard        = "*"
 	anyNameRegexp          = "[^/]*"
+	anyCharRegexp          = "[^/]"
+	anyDirectoriesRegexp   = "(?:.*/)?"
 	anythingRegexp         = ".*"
 	twoStarsReplacement    = "<two-stars-replacement>"
 	endOfLineRegexp        = "$"
 	directorySeparator     = "/"
 )
 
-// FilePattern contains regexp that matches a file
+const (
+	// GitignoreSyntax is a value of pattern_syntax configuration property making the file patterns follow the semantics
+	// of .gitignore files (always used for the default patterns)
+	GitignoreSyntax = "gitignore"
+	// LegacySyntax is a value of pattern_syntax configuration property making the file patterns follow the semantics
+	// used before the gitignore syntax has been introduced (the default one for the configured patterns)
+	LegacySyntax = "legacy"
+)
+
+// FilePattern contains regexp that matches a file together with the original pattern the regexp has been created from.
+// Negated pattern (prefixed with "!") excludes the files matched by the preceding patterns again
 type FilePattern struct {
-	Regexp string
+	Pattern string
+	Regexp  string
+	Negated bool
+	expr    *regexp.Regexp
 }
 
 // Matches checks if the given string (representing path to a file) contains a substring that matches Regexp stored in this matcher
 func (matcher *FilePattern) Matches(filename string) bool {
-	exp, err := regexp.Compile(matcher.Regexp)
-	if err != nil {
-		return false
+	exp := matcher.expr
+	if exp == nil {
+		var err error
+		if exp, err = regexp.Compile(matcher.Regexp); err != nil {
+			return false
+		}
 	}
 	return exp.MatchString(filename)
 }
@@ -34,37 +53,186 @@ func (matcher *FilePattern) Matches(filename string) bool {
 // FilePatterns is an alias type representing slice of FilePattern
 type FilePatterns []FilePattern
 
-// Matches iterates over all patterns and returns first successful match or false if none patterns matched
+// Matches evaluates all patterns in the given order and returns true if the last pattern matching the file is not negated
 func (f *FilePatterns) Matches(filename string) bool {
+	return f.Decisive(filename) != nil
+}
 
-	for _, matcher := range *f {
-		if matcher.Matches(filename) {
-			return true
+// Decisive returns the last pattern matching the given file - the one that decides about the match - or nil if there is
+// no such pattern or if it's negated
+func (f *FilePatterns) Decisive(filename string) *FilePattern {
+	var decisive *FilePattern
+	for i := range *f {
+		if pattern := &(*f)[i]; pattern.Matches(filename) {
+			decisive = pattern
 		}
 	}
-
-	return false
+	if decisive == nil || decisive.Negated {
+		return nil
+	}
+	return decisive
 }
 
-// ParseFilePatterns takes the given patterns and parses to an array of FilePattern instances
+// ParseFilePatterns takes the given patterns and parses to an array of FilePattern instances using the gitignore syntax
 func ParseFilePatterns(filePatterns []string) FilePatterns {
+	return ParseFilePatternsWithSyntax(filePatterns, GitignoreSyntax)
+}
+
+// ParseFilePatternsWithSyntax takes the given patterns and parses to an array of FilePattern instances using the given
+// syntax. When the syntax is not known, then the gitignore one is used
+func ParseFilePatternsWithSyntax(filePatterns []string, syntax string) FilePatterns {
 	patterns := make([]FilePattern, 0, len(filePatterns))
 	for _, pattern := range filePatterns {
-		patterns = append(patterns, FilePattern{
-			Regexp: parseFilePattern(strings.TrimSpace(pattern)),
-		})
+		patterns = append(patterns, newFilePattern(pattern, syntax))
 	}
 	return patterns
 }
 
-func parseFilePattern(pattern string) string {
+func newFilePattern(pattern, syntax string) FilePattern {
+	pattern = strings.TrimSpace(pattern)
+	filePattern := FilePattern{Pattern: pattern}
+	switch {
+	case strings.HasPrefix(pattern, regexpDefinitionPrefix) && strings.HasSuffix(pattern, regexpDefinitionSuffix):
+		// if it is regex{{...}} then just use the content
+		filePattern.Regexp = pattern[len(regexpDefinitionPrefix) : len(pattern)-len(regexpDefinitionSuffix)]
+	case syntax == LegacySyntax:
+		filePattern.Regexp = parseLegacyFilePattern(pattern)
+	default:
+		filePattern.Negated = strings.HasPrefix(pattern, negationPrefix)
+		filePattern.Regexp = parseGitignorePattern(strings.TrimPrefix(pattern, negationPrefix))
+	}
+	// invalid regexp never matches
+	filePattern.expr, _ = regexp.Compile(filePattern.Regexp) // nolint: errcheck
+	return filePattern
+}
+
+// parseGitignorePattern transforms the given pattern to an anchored regexp following the semantics of .gitignore files:
+// a pattern without a separator (other than a trailing one) matches at any level, otherwise it's relative to the root
+// of the repository; a pattern ending with a separator matches everything inside of such directories; ** matches any
+// number of directories, * and ? match any characters except of the separator, [...] is a character class and {a,b}
+// is an alternation. Any character can be escaped by a backslash
+func parseGitignorePattern(pattern string) string {
+	directory := strings.HasSuffix(pattern, directorySeparator)
+	pattern = strings.TrimSuffix(pattern, directorySeparator)
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !strings.Contains(pattern, directorySeparator) {
+		expr.WriteString(anyDirectoriesRegexp)
+	}
+	expr.WriteString(globToRegexp(strings.TrimPrefix(pattern, directorySeparator), true))
+	if directory {
+		expr.WriteString(directorySeparator + anythingRegexp)
+	}
+	expr.WriteString(endOfLineRegexp)
+	return expr.String()
+}
+
+// globToRegexp transforms the given glob to a (not anchored) regexp. The segmentStart flag indicates whether the glob
+// starts at the beginning of a path segment so ** can be treated as any number of directories
+func globToRegexp(glob string, segmentStart bool) string {
+	var expr strings.Builder
+	for i := 0; i < len(glob); i++ {
+		atSegmentStart := (i == 0 && segmentStart) || (i > 0 && glob[i-1] == '/')
+		switch c := glob[i]; {
+		case c == '\\' && i+1 < len(glob):
+			i++
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		case atSegmentStart && strings.HasPrefix(glob[i:], anyPathWildcard+directorySeparator):
+			expr.WriteString(anyDirectoriesRegexp)
+			i += len(anyPathWildcard)
+		case atSegmentStart && glob[i:] == anyPathWildcard:
+			expr.WriteString(anythingRegexp)
+			i += len(anyPathWildcard) - 1
+		case c == '*':
+			for i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+			}
+			expr.WriteString(anyNameRegexp)
+		case c == '?':
+			expr.WriteString(anyCharRegexp)
+		case c == '[':
+			class, length := characterClass(glob[i:])
+			if length == 0 {
+				expr.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+			expr.WriteString(class)
+			i += length - 1
+		case c == '{':
+			alternatives, length := alternation(glob[i:])
+			if length == 0 {
+				expr.WriteString(regexp.QuoteMeta("{"))
+				continue
+			}
+			expr.WriteString("(?:")
+			for index, alternative := range alternatives {
+				if index > 0 {
+					expr.WriteString("|")
+				}
+				expr.WriteString(globToRegexp(alternative, atSegmentStart))
+			}
+			expr.WriteString(")")
+			i += length - 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	return expr.String()
+}
+
+// characterClass transforms the character class the given glob starts with (e.g. [a-z] or [!0-9]) to a regexp which
+// never matches the separator. Returns zero length if the class is not closed
+func characterClass(glob string) (string, int) {
+	end := strings.Index(glob[1:], "]")
+	if end == 0 {
+		// the first ] is a part of the class, e.g. []a]
+		end = strings.Index(glob[2:], "]") + 1
+	}
+	if end <= 0 {
+		return "", 0
+	}
+	content := glob[1 : end+1]
+	negated := strings.HasPrefix(content, "!") || strings.HasPrefix(content, "^")
+	if negated {
+		content = content[1:]
+	}
+	content = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`).Replace(content)
+	if negated {
+		return "[^/" + content + "]", end + 2
+	}
+	return "[" + content + "]", end + 2
+}
 
-	// if it is regex{{...}} then just return the content
-	if strings.HasPrefix(pattern, regexpDefinitionPrefix) && strings.HasSuffix(pattern, regexpDefinitionSuffix) {
-		return pattern[len(regexpDefinitionPrefix) : len(pattern)-len(regexpDefinitionSuffix)]
+// alternation splits the alternation the given glob starts with (e.g. {java,kt}) to its alternatives, nested alternations
+// are kept as they are. Returns zero length if the alternation is not closed
+func alternation(glob string) ([]string, int) {
+	var alternatives []string
+	depth, start := 0, 1
+	for i := 0; i < len(glob); i++ {
+		switch glob[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case ',':
+			if depth == 1 {
+				alternatives = append(alternatives, glob[start:i])
+				start = i + 1
+			}
+		case '}':
+			depth--
+			if depth == 0 {
+				return append(alternatives, glob[start:i]), i + 1
+			}
+		}
 	}
+	return nil, 0
+}
 
-	// if not, then transform the pattern to regexp
+// parseLegacyFilePattern transforms the given pattern to a (not anchored) regexp using the semantics of the patterns
+// used before the gitignore syntax has been introduced
+func parseLegacyFilePattern(pattern string) string {
 	slashIndex := strings.LastIndexAny(pattern, directorySeparator)
 
 	path := transformPathPatternToRegexp(pattern[:slashIndex+1])
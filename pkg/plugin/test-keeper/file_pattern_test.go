@@ -20,7 +20,18 @@ var _ = Describe("File pattern features", func() {
 			parsed := testkeeper.ParseFilePatterns(regexpDef)
 
 			// then
-			Expect(parsed).To(ConsistOf(testkeeper.FilePattern{Regexp: "my-regexp"}))
+			Expect(parsed).To(HaveLen(1))
+			Expect(parsed[0].Pattern).To(Equal("regex{{my-regexp}}"))
+			Expect(parsed[0].Regexp).To(Equal("my-regexp"))
+		})
+
+		It("should parse negated pattern", func() {
+			// when
+			parsed := testkeeper.ParseFilePatterns([]string{"!vendor/acme/"})
+
+			// then
+			Expect(parsed[0].Negated).To(BeTrue())
+			Expect(parsed[0].Regexp).To(Equal("^vendor/acme/.*$"))
 		})
 	})
 
@@ -31,6 +42,11 @@ var _ = Describe("File pattern features", func() {
 			Expect(parsed.Matches(file)).To(BeTrue())
 		}
 
+		var assertThatNot = func(file, pattern string) {
+			parsed := testkeeper.ParseFilePatterns([]string{pattern})
+			Expect(parsed.Matches(file)).To(BeFalse())
+		}
+
 		DescribeTable(
 			"should parse file patterns to regexp",
 			assertThat,
@@ -38,15 +54,76 @@ var _ = Describe("File pattern features", func() {
 			file("Anyfile").matches("**/**/Anyfile"),
 			file("src/Anyfile").matches("*/Anyfile"),
 			file("src/test/resources/Anyfile").matches("src/**/Anyfile"),
-			file("src/test/resources/Anyfile").matches("*/Anyfile"), // FIXME this should fail as it's single directory
 			file("Anyfile").matches("**/Anyfile"),
-			file("test/directory/Anyfile").matches("*/Anyfile"),
 			file("test/multiple/directory/Anyfile").matches("test/multiple/*/Anyfile"),
 			file("Anyfile").matches("Anyfile"),
+			file("pkg/Anyfile").matches("Anyfile"),
 			file("test_case.py").matches("**/test*.py"),
 			file("pkg/test/test_case.py").matches("**/test*.py"),
+			file("pkg/test/test_case.py").matches("test*.py"),
+			file("src/Anyfile").matches("/src/Anyfile"),
+			file("vendor/github.com/acme/lib.go").matches("vendor/"),
+			file("pkg/vendor/lib.go").matches("vendor/"),
+			file("docs/guide/index.html").matches("docs/**"),
+			file("src/main/Greeting.kt").matches("src/**/*.{java,kt}"),
+			file("src/main/Greeting.java").matches("src/**/*.{java,kt}"),
+			file("lib/main/Greeting.java").matches("{src,lib}/**/*.java"),
+			file("Greeting1.java").matches("Greeting?.java"),
+			file("Greeting1.java").matches("Greeting[0-9].java"),
+			file("GreetingA.java").matches("Greeting[!0-9].java"),
+			file("what?.txt").matches(`what\?.txt`),
 		)
 
+		DescribeTable(
+			"should not match files outside of the pattern",
+			assertThatNot,
+			file("src/test/resources/Anyfile").matches("*/Anyfile"),
+			file("test/directory/Anyfile").matches("*/Anyfile"),
+			file("pkg/src/Anyfile").matches("src/Anyfile"),
+			file("src/main/Greeting.scala").matches("src/**/*.{java,kt}"),
+			file("Greeting12.java").matches("Greeting?.java"),
+			file("GreetingA.java").matches("Greeting[0-9].java"),
+			file("Greeting/1.java").matches("Greeting?1.java"),
+			file("docs").matches("docs/**"),
+			file("what1.txt").matches(`what\?.txt`),
+			file("path/to/Test.java/MyAssertion.java").matches("Test*.java"),
+		)
+
+		It("should evaluate negated patterns in the given order", func() {
+			// given
+			patterns := testkeeper.ParseFilePatterns([]string{"vendor/", "!vendor/acme/", "vendor/acme/generated/"})
+
+			// then
+			Expect(patterns.Matches("vendor/github.com/lib.go")).To(BeTrue())
+			Expect(patterns.Matches("vendor/acme/lib.go")).To(BeFalse())
+			Expect(patterns.Matches("vendor/acme/generated/lib.go")).To(BeTrue())
+			Expect(patterns.Decisive("vendor/acme/generated/lib.go").Pattern).To(Equal("vendor/acme/generated/"))
+		})
+	})
+
+	Context("Legacy file pattern matching", func() {
+
+		var assertThat = func(file, pattern string) {
+			parsed := testkeeper.ParseFilePatternsWithSyntax([]string{pattern}, testkeeper.LegacySyntax)
+			Expect(parsed.Matches(file)).To(BeTrue())
+		}
+
+		DescribeTable(
+			"should parse file patterns to regexp",
+			assertThat,
+			file("src/main/resources/Anyfile").matches("**/Anyfile"),
+			file("Anyfile").matches("**/**/Anyfile"),
+			file("src/Anyfile").matches("*/Anyfile"),
+			file("src/test/resources/Anyfile").matches("src/**/Anyfile"),
+			file("src/test/resources/Anyfile").matches("*/Anyfile"), // the legacy syntax matches any number of directories
+			file("Anyfile").matches("**/Anyfile"),
+			file("test/directory/Anyfile").matches("*/Anyfile"),
+			file("test/multiple/directory/Anyfile").matches("test/multiple/*/Anyfile"),
+			file("Anyfile").matches("Anyfile"),
+			file("test_case.py").matches("**/test*.py"),
+			file("pkg/test/test_case.py").matches("**/test*.py"),
+			file("src/test/AwesomeFunctionalTest.java").matches("src/**/*FunctionalTest.java$"),
+		)
 	})
 })
 
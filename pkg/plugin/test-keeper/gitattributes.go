@@ -0,0 +1,116 @@
+package testkeeper
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+
+	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	"github.com/arquillian/ike-prow-plugins/pkg/scm"
+	"github.com/arquillian/ike-prow-plugins/pkg/utils"
+)
+
+// GitAttributesFile is the location of the .gitattributes file test-keeper reads the linguist attributes from
+const GitAttributesFile = ".gitattributes"
+
+// linguistAttributes are attributes marking files which are not written by hand or are not part of the production code.
+// Files having any of them set are skipped from the test verification
+var linguistAttributes = []string{"linguist-generated", "linguist-vendored", "linguist-documentation"}
+
+// GitAttributes holds the rules defined in .gitattributes file which set or unset any of the linguist attributes
+type GitAttributes []gitAttributesRule
+
+type gitAttributesRule struct {
+	pattern    FilePattern
+	attributes map[string]bool
+}
+
+// ParseGitAttributes parses the given content of .gitattributes file. Only the linguist attributes are kept - set
+// either as "attr" or "attr=true", unset as "-attr", "!attr" or "attr=false". As in git, the lines with negative
+// patterns or with patterns matching directories are ignored
+func ParseGitAttributes(content string) GitAttributes {
+	var rules GitAttributes
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		attributes := make(map[string]bool)
+		for _, field := range fields[1:] {
+			name, value := parseGitAttribute(field)
+			if utils.Contains(linguistAttributes, name) {
+				attributes[name] = value
+			}
+		}
+		if len(attributes) == 0 {
+			continue
+		}
+		pattern, valid := newGitAttributesPattern(fields[0])
+		if !valid {
+			continue
+		}
+		pattern.Pattern = line
+		rules = append(rules, gitAttributesRule{pattern: pattern, attributes: attributes})
+	}
+	return rules
+}
+
+// newGitAttributesPattern creates a FilePattern from the pattern of .gitattributes line. The patterns follow the rules
+// of .gitignore files except that negative patterns are forbidden and that a pattern matching a directory doesn't
+// apply to the files inside of it - such patterns never match any file, so they are reported as not valid
+func newGitAttributesPattern(pattern string) (FilePattern, bool) {
+	if strings.HasPrefix(pattern, negationPrefix) || strings.HasSuffix(pattern, directorySeparator) {
+		return FilePattern{}, false
+	}
+	filePattern := FilePattern{Pattern: pattern, Regexp: parseGitignorePattern(pattern)}
+	filePattern.expr, _ = regexp.Compile(filePattern.Regexp) // nolint: errcheck
+	return filePattern, true
+}
+
+func parseGitAttribute(field string) (name string, value bool) {
+	switch {
+	case strings.HasPrefix(field, "-"), strings.HasPrefix(field, "!"):
+		return field[1:], false
+	case strings.Contains(field, "="):
+		parts := strings.SplitN(field, "=", 2)
+		return parts[0], parts[1] != "false"
+	default:
+		return field, true
+	}
+}
+
+// SkippedBy returns the rule (as a FilePattern containing the whole line of .gitattributes file) which marks the given
+// file by any of the linguist attributes or nil if there is no such rule. As in git, the last matching rule setting
+// or unsetting the attribute wins
+func (a GitAttributes) SkippedBy(filename string) *FilePattern {
+	for _, attribute := range linguistAttributes {
+		var decisive *gitAttributesRule
+		for i := range a {
+			rule := &a[i]
+			if _, defined := rule.attributes[attribute]; defined && rule.pattern.Matches(filename) {
+				decisive = rule
+			}
+		}
+		if decisive != nil && decisive.attributes[attribute] {
+			return &decisive.pattern
+		}
+	}
+	return nil
+}
+
+// loadGitAttributes loads the .gitattributes file located in the root of the repository at the given revision.
+// Returns no rules when the file doesn't exist or can't be loaded
+func loadGitAttributes(logger log.Logger, change scm.RepositoryChange) GitAttributes {
+	rawFileService := ghservice.RawFileService{Change: change}
+	content, err := utils.GetFileFromURL(rawFileService.GetRawFileURL(GitAttributesFile))
+	if err != nil {
+		if !utils.IsNotFound(err) {
+			logger.Errorf("failed to load %s so no files are skipped by the linguist attributes. cause: %s", GitAttributesFile, err)
+		}
+		return nil
+	}
+	return ParseGitAttributes(string(content))
+}
@@ -0,0 +1,47 @@
+package testkeeper_test
+
+import (
+	testkeeper "github.com/arquillian/ike-prow-plugins/pkg/plugin/test-keeper"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Git attributes features", func() {
+
+	Context("Finding files marked by linguist attributes", func() {
+
+		attributes := testkeeper.ParseGitAttributes(`
+# generated sources
+*.pb.go linguist-generated=true
+api/client/** linguist-generated
+api/client/handwritten.go -linguist-generated
+third_party/** linguist-vendored
+docs/** linguist-documentation=false
+*.sh text eol=lf
+!tools/gen.go linguist-generated
+generated/ linguist-generated
+`)
+
+		DescribeTable("should skip file marked by any of the linguist attributes",
+			func(file, rule string) {
+				Expect(attributes.SkippedBy(file)).ToNot(BeNil())
+				Expect(attributes.SkippedBy(file).Pattern).To(Equal(rule))
+			},
+			Entry("generated protobuf stub", "pkg/service/greeting.pb.go", "*.pb.go linguist-generated=true"),
+			Entry("generated client", "api/client/greeting_client.go", "api/client/** linguist-generated"),
+			Entry("vendored library", "third_party/lib/lib.go", "third_party/** linguist-vendored"),
+		)
+
+		DescribeTable("should not skip file which is not marked by any of the linguist attributes",
+			func(file string) {
+				Expect(attributes.SkippedBy(file)).To(BeNil())
+			},
+			Entry("regular source", "pkg/service/greeting.go"),
+			Entry("file unmarked by following rule", "api/client/handwritten.go"),
+			Entry("file with the attribute explicitly set to false", "docs/generator.go"),
+			Entry("file with other attributes", "build.sh"),
+			Entry("file matched by forbidden negative pattern", "tools/gen.go"),
+			Entry("file inside of directory matched by directory pattern", "generated/stub.go"),
+		)
+	})
+})
@@ -0,0 +1,148 @@
+package testkeeper
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/assets"
+	"github.com/arquillian/ike-prow-plugins/pkg/config"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	"github.com/arquillian/ike-prow-plugins/pkg/scm"
+	"github.com/pkg/errors"
+)
+
+// languagesCacheTTL is a duration the languages detected for a repository are kept in the cache for
+const languagesCacheTTL = time.Hour
+
+// LanguagePatterns holds the default test patterns, build files and test case signatures of a programming language
+// together with the names GitHub reports the language by. InlineTestPatterns are signatures of tests written directly
+// in the production files (e.g. #[cfg(test)] modules in Rust). It's loaded from the test-keeper/<language>.yaml asset
+type LanguagePatterns struct {
+	GitHubLanguages    []string            `yaml:"github_languages,omitempty"`
+	Inclusions         []string            `yaml:"test_patterns,omitempty"`
+	Exclusions         []string            `yaml:"skip_validation_for,omitempty"`
+	TestCasePatterns   map[string][]string `yaml:"test_case_patterns,omitempty"`
+	InlineTestPatterns map[string][]string `yaml:"inline_test_patterns,omitempty"`
+}
+
+// LoadLanguagePatterns loads the default test patterns, build files and test case signatures of the language with the given name
+func LoadLanguagePatterns(language string) (LanguagePatterns, error) {
+	patterns := LanguagePatterns{}
+	name := strings.ToLower(strings.TrimSpace(language))
+	asset := &assets.LocalLoadableConfig{ConfigFileName: "test-keeper/" + name + ".yaml"}
+	if !asset.Exists() {
+		return patterns, errors.Errorf("unknown language %q - there are no default test patterns defined for it", language)
+	}
+	if err := config.Load(&patterns, asset); err != nil {
+		return patterns, errors.Errorf("an error occurred while loading the default test patterns of %q language: %s", language, err)
+	}
+	return patterns, nil
+}
+
+// detectLanguages selects those of the given known languages the repository (represented by the languages reported
+// by GitHub) is written in
+func detectLanguages(known []string, repoLanguages map[string]int) ([]string, error) {
+	var detected []string
+	for _, language := range known {
+		patterns, err := LoadLanguagePatterns(language)
+		if err != nil {
+			return nil, err
+		}
+		for _, gitHubLanguage := range patterns.GitHubLanguages {
+			if _, found := repoLanguages[gitHubLanguage]; found {
+				detected = append(detected, language)
+				break
+			}
+		}
+	}
+	return detected, nil
+}
+
+// withDetectedLanguages returns the given configuration with the languages detected for the repository of the change -
+// unless they are explicitly configured. When the languages can't be detected, then the configuration is returned
+// as it is, so the patterns of all known languages are used
+func (gh *GitHubTestEventsHandler) withDetectedLanguages(logger log.Logger, change scm.RepositoryChange,
+	configuration *PluginConfiguration) *PluginConfiguration {
+	if len(configuration.Languages) > 0 {
+		return configuration
+	}
+	languages, err := gh.languages.get(change.Owner+"/"+change.RepoName, func() ([]string, error) {
+		repoLanguages, err := gh.Client.ListLanguages(change.Owner, change.RepoName)
+		if err != nil {
+			return nil, err
+		}
+		defaults, err := loadDefaultConfiguration()
+		if err != nil {
+			return nil, err
+		}
+		return detectLanguages(defaults.Languages, repoLanguages)
+	})
+	if err != nil {
+		logger.Errorf("failed to detect languages of the repository so the patterns of all known languages are used. cause: %s", err)
+		return configuration
+	}
+	if len(languages) == 0 {
+		return configuration
+	}
+	withLanguages := *configuration
+	withLanguages.Languages = languages
+	return &withLanguages
+}
+
+// languagesCache keeps the languages detected for the repositories so the languages API is not called for every event.
+// Concurrent requests for the same repository wait for a single detection, failed detections are not cached
+type languagesCache struct {
+	lock     sync.Mutex
+	entries  map[string]cachedLanguages
+	inFlight map[string]*languagesDetection
+}
+
+type cachedLanguages struct {
+	languages []string
+	expiresAt time.Time
+}
+
+// languagesDetection represents the detection of the languages of a repository which is in progress
+type languagesDetection struct {
+	done      chan struct{}
+	languages []string
+	err       error
+}
+
+func (c *languagesCache) get(repository string, detect func() ([]string, error)) ([]string, error) {
+	c.lock.Lock()
+	if cached, found := c.entries[repository]; found && time.Now().Before(cached.expiresAt) {
+		c.lock.Unlock()
+		return cached.languages, nil
+	}
+	if detection, found := c.inFlight[repository]; found {
+		c.lock.Unlock()
+		<-detection.done
+		return detection.languages, detection.err
+	}
+	detection := &languagesDetection{done: make(chan struct{})}
+	if c.inFlight == nil {
+		c.inFlight = make(map[string]*languagesDetection)
+	}
+	c.inFlight[repository] = detection
+	c.lock.Unlock()
+
+	defer c.finish(repository, detection)
+	detection.languages, detection.err = detect()
+	return detection.languages, detection.err
+}
+
+// finish stores the result of the given detection (unless it failed) and releases the requests waiting for it
+func (c *languagesCache) finish(repository string, detection *languagesDetection) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.inFlight, repository)
+	if detection.err == nil {
+		if c.entries == nil {
+			c.entries = make(map[string]cachedLanguages)
+		}
+		c.entries[repository] = cachedLanguages{languages: detection.languages, expiresAt: time.Now().Add(languagesCacheTTL)}
+	}
+	close(detection.done)
+}
@@ -0,0 +1,164 @@
+package testkeeper_test
+
+import (
+	"github.com/arquillian/ike-prow-plugins/pkg/github"
+	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
+	. "github.com/arquillian/ike-prow-plugins/pkg/internal/test"
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+	testkeeper "github.com/arquillian/ike-prow-plugins/pkg/plugin/test-keeper"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gock "gopkg.in/h2non/gock.v1"
+)
+
+// defaultLanguages are GitHub names of all languages test-keeper defines the default test patterns for
+var defaultLanguages = []string{
+	"Java", "Go", "JavaScript", "TypeScript", "Python", "Groovy",
+	"Kotlin", "Scala", "Rust", "C#", "Ruby", "PHP", "Swift", "C", "C++", "Elixir",
+}
+
+var _ = Describe("Test Keeper language detection", func() {
+
+	var handler *testkeeper.GitHubTestEventsHandler
+	var mocker = NewMockPluginTemplate(testkeeper.ProwPluginName)
+
+	log := log.NewTestLogger()
+
+	BeforeEach(func() {
+		defer gock.OffAll()
+		handler = &testkeeper.GitHubTestEventsHandler{Client: NewDefaultGitHubClient(), BotName: botName}
+	})
+
+	AfterEach(EnsureGockRequestsHaveBeenMatched)
+
+	It("should not consider test files of languages the repository is not written in as tests", func() {
+		// given
+		prMock := mocker.MockPr().LoadedFromDefaultJSON().
+			WithoutBaseConfigFiles().
+			WithFiles(javaTestInGoRepository).
+			WithLanguages(`{"Go": 25000, "Shell": 300}`).
+			WithoutRawFiles(ghservice.ConfigHome+"test-keeper_hint.md").
+			WithoutConfigFiles().
+			WithoutMessageFiles("test-keeper_without_tests_message.md").
+			WithoutComments().
+			WithoutReviews().
+			Expecting(
+				Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName)),
+				Comment(To(
+					HaveBodyThatContains("| `pkg/fixtures/TestUtils.java` | production |  |  |")))).
+			Create()
+
+		// when
+		err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+		// then - implicit verification of /statuses call occurrence with proper payload
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should use configured languages instead of detected ones", func() {
+		// given
+		prMock := mocker.MockPr().LoadedFromDefaultJSON().
+			WithFiles(javaTestInGoRepository).
+			WithConfigFile(ConfigYml(`languages:
+  - go
+  - java`)).
+			WithoutComments().
+			Expecting(
+				Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName))).
+			Create()
+
+		// when
+		err := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+
+		// then - implicit verification of /statuses call occurrence with proper payload
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should detect languages of the repository only once", func() {
+		// given
+		prMock := mocker.MockPr().LoadedFromDefaultJSON().
+			WithFiles(javaTestInGoRepository).
+			WithLanguages(`{"Java": 25000}`).
+			WithoutConfigFiles().
+			WithoutComments().
+			WithFiles(javaTestInGoRepository).
+			WithoutConfigFiles().
+			WithoutComments().
+			Expecting(
+				Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName)),
+				Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName))).
+			Create()
+
+		// when
+		firstErr := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+		secondErr := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+		// then - implicit verification that the languages are requested only once
+		Ω(firstErr).ShouldNot(HaveOccurred())
+		Ω(secondErr).ShouldNot(HaveOccurred())
+	})
+
+	It("should detect languages of the repository again when the previous detection failed", func() {
+		// given
+		gock.New("https://api.github.com").
+			Get("/repos/bartoszmajsak/wfswarm-booster-pipeline-test/languages").
+			Reply(500)
+		prMock := mocker.MockPr().LoadedFromDefaultJSON().
+			WithFiles(javaTestInGoRepository).
+			WithoutConfigFiles().
+			WithoutComments().
+			WithFiles(javaTestInGoRepository).
+			WithLanguages(`{"Java": 25000}`).
+			WithoutConfigFiles().
+			WithoutComments().
+			Expecting(
+				Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName)),
+				Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName))).
+			Create()
+
+		// when
+		firstErr := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("opened"))
+		secondErr := handler.HandlePullRequestEvent(log, prMock.CreatePullRequestEvent("synchronize"))
+
+		// then - implicit verification that the languages are requested again
+		Ω(firstErr).ShouldNot(HaveOccurred())
+		Ω(secondErr).ShouldNot(HaveOccurred())
+	})
+})
+
+var _ = Describe("Default language patterns", func() {
+
+	It("should load test patterns of the given language", func() {
+		// when
+		patterns, err := testkeeper.LoadLanguagePatterns("Go")
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(patterns.GitHubLanguages).To(ConsistOf("Go"))
+		Expect(patterns.Inclusions).To(ConsistOf("*_test.go"))
+	})
+
+	It("should fail loading matcher for unknown language", func() {
+		// when
+		_, err := testkeeper.LoadMatcher(&testkeeper.PluginConfiguration{Languages: []string{"cobol"}})
+
+		// then
+		Ω(err).Should(MatchError(ContainSubstring(`unknown language "cobol"`)))
+	})
+
+	It("should load default matcher only with patterns of the given languages", func() {
+		// when
+		matcher, err := testkeeper.LoadDefaultMatcherForLanguages([]string{"python"})
+
+		// then
+		Ω(err).ShouldNot(HaveOccurred())
+		Expect(matcher.MatchesInclusion("tests/test_greeting.py")).To(BeTrue())
+		Expect(matcher.MatchesInclusion("src/test/java/GreetingTest.java")).To(BeFalse())
+		Expect(matcher.MatchesExclusion("README.md")).To(BeTrue())
+	})
+})
+
+const javaTestInGoRepository = `[
+	{"filename":"pkg/greeting/greeting.go", "status":"modified", "additions":12, "deletions":3},
+	{"filename":"pkg/fixtures/TestUtils.java", "status":"added", "additions":30, "deletions":0}
+]`
@@ -8,9 +8,15 @@ import (
 
 // TestMatcher holds definitions of patterns considered as test filenames (inclusions) and those which shouldn't be
 // verified (exclusions)
+// together with the signatures of test cases used to detect new test cases added to the test files
+// and the signatures of inline tests which make a production file count as a test when its patch adds them.
+// Files marked by any of the linguist attributes in .gitattributes (when loaded) are not verified either
 type TestMatcher struct {
-	Inclusion []FilePattern
-	Exclusion []FilePattern
+	Inclusion   []FilePattern
+	Exclusion   []FilePattern
+	TestCases   []TestCaseSignature
+	InlineTests []TestCaseSignature
+	Attributes  GitAttributes
 }
 
 // MatchesInclusion checks if file name matches defined inclusion patterns
@@ -18,35 +24,65 @@ func (matcher *TestMatcher) MatchesInclusion(filename string) bool {
 	return Matches(matcher.Inclusion, filename)
 }
 
-// MatchesExclusion checks if file name matches defined exclusion patterns
+// MatchesExclusion checks if file name matches defined exclusion patterns or if it's marked by any of the linguist attributes
 func (matcher *TestMatcher) MatchesExclusion(filename string) bool {
-	return Matches(matcher.Exclusion, filename)
+	return Matches(matcher.Exclusion, filename) || matcher.Attributes.SkippedBy(filename) != nil
 }
 
-// Matches iterates over a slice of FilePattern and verifies if passed name matches any of the defined patterns
+// Matches evaluates a slice of FilePattern in the given order and verifies if passed name matches any of the defined
+// patterns without being negated by any of the following ones
 func Matches(matchers []FilePattern, filename string) bool {
+	patterns := FilePatterns(matchers)
+	return patterns.Matches(filename)
+}
+
+// LoadDefaultMatcher loads default matcher containing default exclude patterns together with include patterns and
+// test case signatures of all known languages
+func LoadDefaultMatcher() (TestMatcher, error) {
+	return LoadDefaultMatcherForLanguages(nil)
+}
+
+// LoadDefaultMatcherForLanguages loads default matcher containing default exclude patterns together with include patterns,
+// build files and test case signatures of the given languages. When there is no language given, then all known
+// languages are used
+func LoadDefaultMatcherForLanguages(languages []string) (TestMatcher, error) {
+	matcher := TestMatcher{}
+	defaultConfig, err := loadDefaultConfiguration()
+	if err != nil {
+		return matcher, err
+	}
+	if len(languages) == 0 {
+		languages = defaultConfig.Languages
+	}
 
-	for _, matcher := range matchers {
-		if matcher.Matches(filename) {
-			return true
+	matcher.Exclusion = ParseFilePatterns(defaultConfig.Exclusions)
+	for _, language := range languages {
+		patterns, err := LoadLanguagePatterns(language)
+		if err != nil {
+			return matcher, err
 		}
+		testCases, err := ParseTestCaseSignatures(patterns.TestCasePatterns)
+		if err != nil {
+			return matcher, err
+		}
+		inlineTests, err := ParseTestCaseSignatures(patterns.InlineTestPatterns)
+		if err != nil {
+			return matcher, err
+		}
+		matcher.Inclusion = append(matcher.Inclusion, ParseFilePatterns(patterns.Inclusions)...)
+		matcher.Exclusion = append(matcher.Exclusion, ParseFilePatterns(patterns.Exclusions)...)
+		matcher.TestCases = append(matcher.TestCases, testCases...)
+		matcher.InlineTests = append(matcher.InlineTests, inlineTests...)
 	}
 
-	return false
+	return matcher, nil
 }
 
-// LoadDefaultMatcher loads default matcher containing default include and exclude patterns
-func LoadDefaultMatcher() (TestMatcher, error) {
-	matcher := TestMatcher{}
+func loadDefaultConfiguration() (PluginConfiguration, error) {
 	defaultConfig := PluginConfiguration{}
-
 	err := config.Load(&defaultConfig, &assets.LocalLoadableConfig{ConfigFileName: "test-keeper.yaml"})
-
 	if err != nil {
-		return matcher, errors.Errorf("an error occurred while loading the default test-keeper.yaml: %s", err)
+		return defaultConfig, errors.Errorf("an error occurred while loading the default test-keeper.yaml: %s", err)
 	}
-	matcher.Inclusion = ParseFilePatterns(defaultConfig.Inclusions)
-	matcher.Exclusion = ParseFilePatterns(defaultConfig.Exclusions)
-
-	return matcher, nil
+	return defaultConfig, nil
 }
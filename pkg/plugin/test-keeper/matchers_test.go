@@ -117,6 +117,165 @@ var _ = Describe("Test Matcher features", func() {
 			Expect(matchers.Inclusion).To(HaveLen(1))
 			Expect(matchers).To(WithTransform(firstRegexp, Equal("*IT.java|*TestCase.java")))
 		})
+
+		It("should use legacy syntax for configured patterns when no syntax is set", func() {
+			// given
+			configuration := &PluginConfiguration{Inclusions: []string{"*Spec.java"}}
+
+			// when
+			matchers, err := LoadMatcher(configuration)
+
+			// then
+			Ω(err).ShouldNot(HaveOccurred())
+			Expect(matchers.Inclusion).To(HaveLen(1))
+			Expect(matchers.Inclusion[0].Regexp).To(Equal(".*Spec\\.java$"))
+		})
+	})
+
+	Context("Explaining file categories", func() {
+
+		It("should explain category of each file with the pattern it is matched by", func() {
+			// given
+			matcher, err := LoadMatcher(&PluginConfiguration{
+				Inclusions:    []string{"**/*Spec.java"},
+				Exclusions:    []string{"docs/**"},
+				Combine:       true,
+				PatternSyntax: GitignoreSyntax,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// when
+			specExplained := matcher.Explain("src/test/java/GreetingSpec.java")
+			docExplained := matcher.Explain("docs/Greeting.java")
+			productionExplained := matcher.Explain("src/main/java/Greeting.java")
+
+			// then
+			Expect(specExplained.Category).To(Equal(TestCategory))
+			Expect(specExplained.Pattern.Pattern).To(Equal("**/*Spec.java"))
+			Expect(specExplained.Pattern.Regexp).To(Equal("^(?:.*/)?[^/]*Spec\\.java$"))
+			Expect(docExplained.Category).To(Equal(SkippedCategory))
+			Expect(docExplained.Pattern.Pattern).To(Equal("docs/**"))
+			Expect(productionExplained).To(Equal(FileExplanation{File: "src/main/java/Greeting.java", Category: ProductionCategory}))
+		})
+	})
+
+	Context("Predefined language patterns applied to project trees", func() {
+
+		DescribeTable("should categorize files of the project",
+			func(language string, tree map[string]string) {
+				// given
+				matcher, err := LoadDefaultMatcherForLanguages([]string{language})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				// when
+				categories := make(map[string]string, len(tree))
+				for file := range tree {
+					categories[file] = matcher.Explain(file).Category
+				}
+
+				// then
+				Expect(categories).To(Equal(tree))
+			},
+			Entry("Kotlin", "kotlin", map[string]string{
+				"app/src/main/kotlin/com/acme/Cart.kt":            ProductionCategory,
+				"app/src/test/kotlin/com/acme/CartTest.kt":        TestCategory,
+				"app/src/test/kotlin/com/acme/CartSpec.kt":        TestCategory,
+				"app/src/integrationTest/kotlin/com/acme/DbIT.kt": TestCategory,
+				"app/build.gradle.kts":                            SkippedCategory,
+				"settings.gradle.kts":                             SkippedCategory,
+				"gradle.properties":                               SkippedCategory,
+			}),
+			Entry("Scala", "scala", map[string]string{
+				"core/src/main/scala/acme/Cart.scala":      ProductionCategory,
+				"core/src/test/scala/acme/CartSpec.scala":  TestCategory,
+				"core/src/test/scala/acme/CartSuite.scala": TestCategory,
+				"build.sbt":                SkippedCategory,
+				"project/plugins.sbt":      SkippedCategory,
+				"project/build.properties": SkippedCategory,
+				".scalafmt.conf":           SkippedCategory,
+			}),
+			Entry("Rust", "rust", map[string]string{
+				"src/lib.rs":                        ProductionCategory,
+				"src/cart/mod.rs":                   ProductionCategory,
+				"tests/cart.rs":                     TestCategory,
+				"tests/common/mod.rs":               TestCategory,
+				"crates/parser/src/lexer.rs":        ProductionCategory,
+				"crates/parser/tests/lexer_test.rs": TestCategory,
+				"Cargo.toml":                        SkippedCategory,
+				"Cargo.lock":                        SkippedCategory,
+				"crates/parser/Cargo.toml":          SkippedCategory,
+				"crates/parser/build.rs":            SkippedCategory,
+				"rust-toolchain.toml":               SkippedCategory,
+			}),
+			Entry("C#", "csharp", map[string]string{
+				"src/Acme.Cart/Cart.cs":                         ProductionCategory,
+				"src/Acme.Cart/Properties/AssemblyInfo.cs":      SkippedCategory,
+				"src/Acme.Cart/Acme.Cart.csproj":                SkippedCategory,
+				"tests/Acme.Cart.Tests/CartTests.cs":            TestCategory,
+				"tests/Acme.Cart.Tests/Fixtures/CartBuilder.cs": TestCategory,
+				"tests/Acme.Cart.UnitTests/PriceCalculator.cs":  TestCategory,
+				"tests/Acme.Cart.Tests/Acme.Cart.Tests.csproj":  SkippedCategory,
+				"Acme.sln":              SkippedCategory,
+				"Directory.Build.props": SkippedCategory,
+				"NuGet.Config":          SkippedCategory,
+				"global.json":           SkippedCategory,
+			}),
+			Entry("Ruby", "ruby", map[string]string{
+				"lib/acme/cart.rb":          ProductionCategory,
+				"app/models/order.rb":       ProductionCategory,
+				"spec/acme/cart_spec.rb":    TestCategory,
+				"test/models/order_test.rb": TestCategory,
+				"test/test_helper.rb":       TestCategory,
+				"spec/spec_helper.rb":       ProductionCategory,
+				"Gemfile":                   SkippedCategory,
+				"Gemfile.lock":              SkippedCategory,
+				"acme.gemspec":              SkippedCategory,
+				".rspec":                    SkippedCategory,
+			}),
+			Entry("PHP", "php", map[string]string{
+				"src/Cart.php":                      ProductionCategory,
+				"tests/Unit/CartTest.php":           TestCategory,
+				"tests/Acceptance/CheckoutCest.php": TestCategory,
+				"spec/CartSpec.php":                 TestCategory,
+				"composer.json":                     SkippedCategory,
+				"composer.lock":                     SkippedCategory,
+				"phpunit.xml.dist":                  SkippedCategory,
+				"phpstan.neon":                      SkippedCategory,
+			}),
+			Entry("Swift", "swift", map[string]string{
+				"Sources/Cart/Cart.swift":                ProductionCategory,
+				"Tests/CartTests/CartTests.swift":        TestCategory,
+				"Tests/CartTests/Helpers/Fixtures.swift": TestCategory,
+				"App/AcmeUITests/CheckoutUITests.swift":  TestCategory,
+				"Package.swift":                          SkippedCategory,
+				"Package.resolved":                       SkippedCategory,
+				"Podfile.lock":                           SkippedCategory,
+				"Acme.xcodeproj/project.pbxproj":         SkippedCategory,
+			}),
+			Entry("C/C++", "cpp", map[string]string{
+				"src/cart.cpp":                  ProductionCategory,
+				"include/acme/cart.hpp":         ProductionCategory,
+				"lib/price.c":                   ProductionCategory,
+				"src/cart_test.cpp":             TestCategory,
+				"src/price_unittest.cc":         TestCategory,
+				"test/test_price.c":             TestCategory,
+				"tests/fixtures/cart_fixture.h": TestCategory,
+				"CMakeLists.txt":                SkippedCategory,
+				"tests/CMakeLists.txt":          SkippedCategory,
+				"cmake/FindGTest.cmake":         SkippedCategory,
+				"conanfile.py":                  SkippedCategory,
+				"vcpkg.json":                    SkippedCategory,
+			}),
+			Entry("Elixir", "elixir", map[string]string{
+				"lib/acme/cart.ex":                ProductionCategory,
+				"test/acme/cart_test.exs":         TestCategory,
+				"apps/web/test/checkout_test.exs": TestCategory,
+				"mix.exs":                         SkippedCategory,
+				"mix.lock":                        SkippedCategory,
+				"config/config.exs":               SkippedCategory,
+				".formatter.exs":                  SkippedCategory,
+			}),
+		)
 	})
 
 	Context("Predefined exclusion regexp check (DefaultMatchers)", func() {
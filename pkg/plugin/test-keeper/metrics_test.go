@@ -30,6 +30,10 @@ var _ = Describe("TestKeeper Metrics", func() {
 		mocker = NewMockPluginTemplate(testkeeper.ProwPluginName)
 	})
 
+	JustBeforeEach(func() {
+		AnyRepositoryWrittenIn(defaultLanguages...)
+	})
+
 	AfterEach(func() {
 		testkeeper.UnRegisterAndResetMetrics()
 		EnsureGockRequestsHaveBeenMatched()
@@ -42,6 +46,7 @@ var _ = Describe("TestKeeper Metrics", func() {
 		approvedBy := fmt.Sprintf(testkeeper.ApprovedByMessage, "admin")
 
 		mockPrBuilder := mocker.MockPr().LoadedFromDefaultJSON().
+			WithoutBaseConfigFiles().
 			WithSize(2).
 			WithoutConfigFiles().
 			WithUsers(Admin("admin")).
@@ -81,6 +86,7 @@ var _ = Describe("TestKeeper Metrics", func() {
 		prMock := mocker.MockPr().LoadedFromDefaultJSON().
 			WithoutConfigFiles().
 			WithoutComments().
+			WithoutReviews().
 			WithFiles(LoadedFrom("test_fixtures/github_calls/prs/with_tests/changes.json")).
 			Expecting(
 				Status(ToBe(github.StatusSuccess, testkeeper.TestsExistMessage, testkeeper.TestsExistDetailsPageName))).
@@ -98,9 +104,11 @@ var _ = Describe("TestKeeper Metrics", func() {
 	It("should report pull requests without tests", func() {
 		//given
 		prMock := mocker.MockPr().LoadedFromDefaultJSON().
+			WithoutBaseConfigFiles().
 			WithoutConfigFiles().
 			WithoutMessageFiles("test-keeper_without_tests_message.md").
 			WithoutComments().
+			WithoutReviews().
 			WithFiles(LoadedFrom("test_fixtures/github_calls/prs/without_tests/changes.json")).
 			Expecting(
 				Status(ToBe(github.StatusFailure, testkeeper.NoTestsMessage, testkeeper.NoTestsDetailsPageName)),
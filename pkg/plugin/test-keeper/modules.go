@@ -0,0 +1,253 @@
+package testkeeper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/scm"
+)
+
+// ModuleConfiguration defines test-keeper rules for the files located in a particular directory (module) of the repository.
+// The patterns are combined with those defined for the whole repository unless inherit_patterns is set to false - then
+// they replace them (unlike combine_defaults of the repository configuration, it doesn't refer to the default patterns
+// of the plugin, so it has a different name and the default patterns are still used unless combine_defaults is false),
+// thresholds, pairing rules and languages which are not set are inherited from the repository configuration
+type ModuleConfiguration struct {
+	Inclusions               []string          `yaml:"test_patterns,omitempty"`
+	Exclusions               []string          `yaml:"skip_validation_for,omitempty"`
+	InheritPatterns          bool              `yaml:"inherit_patterns,omitempty"`
+	MinTestLinesRatio        float64           `yaml:"min_test_lines_ratio,omitempty"`
+	MinTestFilesRatio        float64           `yaml:"min_test_files_ratio,omitempty"`
+	SkipValidationBelowLines int               `yaml:"skip_validation_below_lines,omitempty"`
+	PairingRules             []TestPairingRule `yaml:"test_pairs,omitempty"`
+	Languages                []string          `yaml:"languages,omitempty"`
+}
+
+// UnmarshalYAML sets inherit_patterns to true when it's not defined for the module
+func (m *ModuleConfiguration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain ModuleConfiguration
+	module := plain{InheritPatterns: true}
+	if err := unmarshal(&module); err != nil {
+		return err
+	}
+	*m = ModuleConfiguration(module)
+	return nil
+}
+
+// testOutcome is a result of the test verification of a module. The outcomes are ordered by their severity, so the
+// outcome of the whole pull request is the most severe outcome of all touched modules
+type testOutcome int
+
+const (
+	outcomeOnlySkipped testOutcome = iota
+	outcomeSmallChange
+	outcomeTestsExist
+	outcomeInsufficientTests
+	outcomeMissingTests
+	outcomeNoTests
+)
+
+func (o testOutcome) successful() bool {
+	return o <= outcomeTestsExist
+}
+
+func (o testOutcome) String() string {
+	switch o {
+	case outcomeOnlySkipped:
+		return ":white_check_mark: no tests needed"
+	case outcomeSmallChange:
+		return ":white_check_mark: small change"
+	case outcomeTestsExist:
+		return ":white_check_mark: tests exist"
+	case outcomeInsufficientTests:
+		return ":x: not enough tests"
+	case outcomeMissingTests:
+		return ":x: missing paired tests"
+	default:
+		return ":x: no tests"
+	}
+}
+
+// moduleCheck holds the file categories of the files changed in a module together with the configuration of the module
+// and the matcher the files have been categorized by.
+// The module with an empty path represents all the files not belonging to any of the configured modules
+type moduleCheck struct {
+	path          string
+	configuration *PluginConfiguration
+	matcher       TestMatcher
+	categories    FileCategories
+}
+
+func (m moduleCheck) outcome() testOutcome {
+	categories := m.categories
+	switch {
+	case categories.OnlySkippedFiles():
+		return outcomeOnlySkipped
+	case categories.TestsExist() && m.configuration.ratioThresholdsSatisfied(categories) && categories.AllTestsPaired():
+		return outcomeTestsExist
+	case m.configuration.belowSizeThreshold(categories):
+		return outcomeSmallChange
+	case !categories.TestsExist():
+		return outcomeNoTests
+	case !categories.AllTestsPaired():
+		return outcomeMissingTests
+	default:
+		return outcomeInsufficientTests
+	}
+}
+
+// report creates a markdown summary of the numbers the configured thresholds are computed from together with the lists
+// of files missing paired tests and of tests which don't add any new test case
+func (m moduleCheck) report() string {
+	var reports []string
+	if m.configuration.thresholdsConfigured() {
+		reports = append(reports, m.configuration.thresholdsReport(m.categories))
+	}
+	if missing := missingTestsReport(m.categories.MissingTests); missing != "" {
+		reports = append(reports, missing)
+	}
+	if withoutNewCases := testsWithoutNewCasesReport(m.categories.TestsWithoutNewCases); withoutNewCases != "" {
+		reports = append(reports, withoutNewCases)
+	}
+	return strings.Join(reports, paragraph)
+}
+
+func (m moduleCheck) name() string {
+	if m.path == "" {
+		return "rest of the repository"
+	}
+	return fmt.Sprintf("`%s/`", m.path)
+}
+
+// moduleChecks are results of the test verification of all modules touched by the pull request
+type moduleChecks []moduleCheck
+
+func (checks moduleChecks) outcome() testOutcome {
+	overall := outcomeOnlySkipped
+	for _, check := range checks {
+		if outcome := check.outcome(); outcome > overall {
+			overall = outcome
+		}
+	}
+	return overall
+}
+
+// report creates a markdown summary of the test verification. When there are modules configured, it contains a breakdown
+// of the outcome of each of the touched modules
+func (checks moduleChecks) report() string {
+	if len(checks) == 1 && checks[0].path == "" {
+		return checks[0].report()
+	}
+
+	var report strings.Builder
+	report.WriteString("| Module | Production files | Test files | Result |\n|---|---|---|---|\n")
+	for _, check := range checks {
+		report.WriteString(fmt.Sprintf("| %s | %d | %d | %s |\n",
+			check.name(), check.categories.Production, check.categories.Tests, check.outcome()))
+	}
+	for _, check := range checks {
+		if details := check.report(); details != "" {
+			report.WriteString(fmt.Sprintf("\n**%s**\n\n%s\n", check.name(), details))
+		}
+	}
+	return strings.TrimSpace(report.String())
+}
+
+// removedTests returns the tests removed or shrunk in all modules when the configuration requires to flag them.
+// The lines are summed up over all the modules, so a test moved from one module to another isn't considered as removed
+func (checks moduleChecks) removedTests(configuration *PluginConfiguration) []string {
+	if configuration.RemovedTests == "" {
+		return nil
+	}
+	var shrunk []string
+	var added, deleted int
+	for _, check := range checks {
+		shrunk = append(shrunk, check.categories.ShrunkTests...)
+		added += check.categories.TestLinesAdded
+		deleted += check.categories.TestLinesDeleted
+	}
+	if deleted <= added {
+		return nil
+	}
+	return shrunk
+}
+
+func (checks moduleChecks) testsExist() bool {
+	for _, check := range checks {
+		if check.categories.TestsExist() {
+			return true
+		}
+	}
+	return false
+}
+
+// forModule creates a configuration applicable to the files of the module with the given path
+func (c *PluginConfiguration) forModule(path string) *PluginConfiguration {
+	if path == "" {
+		return c
+	}
+	var module ModuleConfiguration
+	for modulePath, moduleConfiguration := range c.Modules {
+		if strings.Trim(modulePath, directorySeparator) == path {
+			module = moduleConfiguration
+			break
+		}
+	}
+	configuration := *c
+	if module.InheritPatterns {
+		configuration.Inclusions = append(append([]string{}, c.Inclusions...), module.Inclusions...)
+		configuration.Exclusions = append(append([]string{}, c.Exclusions...), module.Exclusions...)
+	} else {
+		configuration.Inclusions, configuration.Exclusions = module.Inclusions, module.Exclusions
+	}
+	if module.MinTestLinesRatio > 0 {
+		configuration.MinTestLinesRatio = module.MinTestLinesRatio
+	}
+	if module.MinTestFilesRatio > 0 {
+		configuration.MinTestFilesRatio = module.MinTestFilesRatio
+	}
+	if module.SkipValidationBelowLines > 0 {
+		configuration.SkipValidationBelowLines = module.SkipValidationBelowLines
+	}
+	if len(module.PairingRules) > 0 {
+		configuration.PairingRules = module.PairingRules
+	}
+	if len(module.Languages) > 0 {
+		configuration.Languages = module.Languages
+	}
+	return &configuration
+}
+
+// splitIntoModules assigns the changed files to the configured modules. A file belongs to the module with the longest
+// path the file is located in, files not belonging to any module are assigned to the module with an empty path
+func (c *PluginConfiguration) splitIntoModules(files []scm.ChangedFile) (paths []string, modules map[string][]scm.ChangedFile) {
+	modulePaths := make([]string, 0, len(c.Modules))
+	for path := range c.Modules {
+		modulePaths = append(modulePaths, strings.Trim(path, directorySeparator))
+	}
+	sort.Slice(modulePaths, func(i, j int) bool {
+		return len(modulePaths[i]) > len(modulePaths[j])
+	})
+
+	modules = make(map[string][]scm.ChangedFile)
+	for _, file := range files {
+		module := ""
+		for _, path := range modulePaths {
+			if strings.HasPrefix(file.Name, path+directorySeparator) {
+				module = path
+				break
+			}
+		}
+		if _, found := modules[module]; !found {
+			paths = append(paths, module)
+		}
+		modules[module] = append(modules[module], file)
+	}
+
+	if len(paths) == 0 {
+		paths = append(paths, "")
+	}
+	sort.Strings(paths)
+	return paths, modules
+}
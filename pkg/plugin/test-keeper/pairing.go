@@ -0,0 +1,173 @@
+package testkeeper
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/log"
+)
+
+const (
+	dirPlaceholder  = "{dir}"
+	namePlaceholder = "{name}"
+	extPlaceholder  = "{ext}"
+)
+
+// TestPairingRule maps production files matching the Source pattern to the templates of paths of the tests which are
+// expected to be changed together with them. The templates may contain placeholders {dir} (directory of the production
+// file), {name} (file name without extension) and {ext} (extension including the dot), e.g.:
+//
+//	source: src/main/java/**/*.java
+//	tests: [src/test/java/**/{name}Test.java]
+type TestPairingRule struct {
+	Source string   `yaml:"source"`
+	Tests  []string `yaml:"tests"`
+}
+
+// MissingTest represents a changed production file for which none of the expected tests has been changed
+type MissingTest struct {
+	File     string
+	Expected []string
+}
+
+type testPairing struct {
+	source FilePatterns
+	tests  []string
+}
+
+// validTestPairingRules returns the given rules without the test templates which can't be turned into a regexp - each
+// of them is logged as an error. A rule without any valid template is dropped
+func validTestPairingRules(logger log.Logger, rules []TestPairingRule) []TestPairingRule {
+	valid := make([]TestPairingRule, 0, len(rules))
+	for _, rule := range rules {
+		validRule := TestPairingRule{Source: rule.Source}
+		for _, template := range rule.Tests {
+			if _, err := regexp.Compile(templateToRegexp(template, "dir/name.ext")); err != nil {
+				logger.Errorf("test pair template %q of the source %q is ignored as it is not valid. cause: %s", template, rule.Source, err)
+				continue
+			}
+			validRule.Tests = append(validRule.Tests, template)
+		}
+		if len(validRule.Tests) > 0 {
+			valid = append(valid, validRule)
+		}
+	}
+	return valid
+}
+
+func parseTestPairingRules(rules []TestPairingRule) []testPairing {
+	pairings := make([]testPairing, 0, len(rules))
+	for _, rule := range rules {
+		pairings = append(pairings, testPairing{source: ParseFilePatterns([]string{rule.Source}), tests: rule.Tests})
+	}
+	return pairings
+}
+
+// missingTest checks if any of the changed files is a test expected by the first rule matching the given production
+// file. Returns nil if there is no rule for the file or if the paired test is present
+func missingTest(pairings []testPairing, production string, changedFiles []string) *MissingTest {
+	for _, pairing := range pairings {
+		if !pairing.source.Matches(production) {
+			continue
+		}
+		missing := &MissingTest{File: production}
+		for _, template := range pairing.tests {
+			expected, err := regexp.Compile(templateToRegexp(template, production))
+			for _, test := range changedFiles {
+				if err == nil && expected.MatchString(test) {
+					return nil
+				}
+			}
+			missing.Expected = append(missing.Expected, expandTemplate(template, production, func(s string) string { return s }))
+		}
+		return missing
+	}
+	return nil
+}
+
+// templateToRegexp creates an anchored regexp matching the paths of tests expected by the given template for the given
+// production file. The placeholders are replaced by the literal values, ** matches any number of directories,
+// * and ? match any characters except of the directory separator. Any other character (including backslash) is matched
+// literally
+func templateToRegexp(template, production string) string {
+	values := placeholderValues(production, regexp.QuoteMeta)
+	if values[dirPlaceholder] == "" {
+		template = strings.Replace(template, dirPlaceholder+directorySeparator, "", -1)
+	}
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(template); i++ {
+		if placeholder := placeholderAt(template[i:]); placeholder != "" {
+			expr.WriteString(values[placeholder])
+			i += len(placeholder) - 1
+			continue
+		}
+		switch {
+		case strings.HasPrefix(template[i:], anyPathWildcard+directorySeparator):
+			expr.WriteString("(?:.*/)?")
+			i += len(anyPathWildcard)
+		case strings.HasPrefix(template[i:], anyPathWildcard):
+			expr.WriteString(anythingRegexp)
+			i += len(anyPathWildcard) - 1
+		case template[i] == '*':
+			expr.WriteString(anyNameRegexp)
+		case template[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(template[i : i+1]))
+		}
+	}
+	expr.WriteString(endOfLineRegexp)
+	return expr.String()
+}
+
+// expandTemplate replaces placeholders in the given template with values derived from the given production file
+// transformed by the given function
+func expandTemplate(template, production string, transform func(string) string) string {
+	values := placeholderValues(production, transform)
+	if values[dirPlaceholder] == "" {
+		template = strings.Replace(template, dirPlaceholder+directorySeparator, "", -1)
+	}
+	return strings.NewReplacer(
+		dirPlaceholder, values[dirPlaceholder],
+		namePlaceholder, values[namePlaceholder],
+		extPlaceholder, values[extPlaceholder],
+	).Replace(template)
+}
+
+// placeholderValues returns the values of the placeholders derived from the given production file transformed by
+// the given function
+func placeholderValues(production string, transform func(string) string) map[string]string {
+	dir, file := path.Split(production)
+	ext := path.Ext(file)
+	return map[string]string{
+		dirPlaceholder:  transform(strings.TrimSuffix(dir, directorySeparator)),
+		namePlaceholder: transform(strings.TrimSuffix(file, ext)),
+		extPlaceholder:  transform(ext),
+	}
+}
+
+// placeholderAt returns the placeholder the given part of a template starts with or an empty string if there is none
+func placeholderAt(template string) string {
+	for _, placeholder := range []string{dirPlaceholder, namePlaceholder, extPlaceholder} {
+		if strings.HasPrefix(template, placeholder) {
+			return placeholder
+		}
+	}
+	return ""
+}
+
+// missingTestsReport creates a markdown list of production files which are missing the paired tests
+func missingTestsReport(missingTests []MissingTest) string {
+	if len(missingTests) == 0 {
+		return ""
+	}
+	var report strings.Builder
+	report.WriteString("The following changed files are missing paired tests:\n\n")
+	for _, missing := range missingTests {
+		report.WriteString(fmt.Sprintf("* `%s` - expected a change in `%s`\n", missing.File, strings.Join(missing.Expected, "` or `")))
+	}
+	return strings.TrimSpace(report.String())
+}
@@ -0,0 +1,119 @@
+package testkeeper
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/arquillian/ike-prow-plugins/pkg/scm"
+	"github.com/pkg/errors"
+)
+
+// TestCaseSignature holds regular expressions matching lines which add a new test case to the files matching the Files pattern
+type TestCaseSignature struct {
+	Files       FilePattern
+	Signatures  []string
+	expressions []*regexp.Regexp
+}
+
+// ParseTestCaseSignatures takes the given mapping of file patterns to regular expressions of test case signatures
+// and parses it to an array of TestCaseSignature instances
+func ParseTestCaseSignatures(signatures map[string][]string) ([]TestCaseSignature, error) {
+	patterns := make([]string, 0, len(signatures))
+	for pattern := range signatures {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	parsed := make([]TestCaseSignature, 0, len(signatures))
+	for _, pattern := range patterns {
+		expressions := make([]*regexp.Regexp, 0, len(signatures[pattern]))
+		for _, expr := range signatures[pattern] {
+			compiled, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, errors.Errorf("invalid test case pattern %q defined for %q: %s", expr, pattern, err)
+			}
+			expressions = append(expressions, compiled)
+		}
+		parsed = append(parsed, TestCaseSignature{
+			Files:       newFilePattern(pattern, GitignoreSyntax),
+			Signatures:  signatures[pattern],
+			expressions: expressions,
+		})
+	}
+	return parsed, nil
+}
+
+// signaturesFor returns the regular expressions of test case signatures defined for the given file name
+func signaturesFor(signatures []TestCaseSignature, filename string) []*regexp.Regexp {
+	var expressions []*regexp.Regexp
+	for _, signature := range signatures {
+		if signature.Files.Matches(filename) {
+			expressions = append(expressions, signature.expressions...)
+		}
+	}
+	return expressions
+}
+
+// inlineTestsSignature returns a pattern holding the file pattern and the signature of the inline tests added by the patch
+// of the given file or nil if the patch doesn't add any (or if it's not available)
+func inlineTestsSignature(signatures []TestCaseSignature, file scm.ChangedFile) *FilePattern {
+	if file.Patch == "" {
+		return nil
+	}
+	for _, signature := range signatures {
+		if !signature.Files.Matches(file.Name) {
+			continue
+		}
+		for _, expr := range signature.expressions {
+			if countAddedTestCases(file.Patch, []*regexp.Regexp{expr}) > 0 {
+				return &FilePattern{Pattern: signature.Files.Pattern, Regexp: expr.String(), expr: expr}
+			}
+		}
+	}
+	return nil
+}
+
+// countAddedTestCases counts the lines added by the given patch which match any of the given test case signatures
+func countAddedTestCases(patch string, signatures []*regexp.Regexp) int {
+	count := 0
+	for _, line := range strings.Split(patch, "\n") {
+		if !strings.HasPrefix(line, "+") || strings.HasPrefix(line, "+++") {
+			continue
+		}
+		for _, signature := range signatures {
+			if signature.MatchString(line[1:]) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// testsWithoutNewCasesReport creates a markdown list of test files which are changed without adding any new test case
+func testsWithoutNewCasesReport(tests []string) string {
+	if len(tests) == 0 {
+		return ""
+	}
+	var report strings.Builder
+	report.WriteString("The following test files are changed, but no new test case has been added to them:\n\n")
+	for _, test := range tests {
+		report.WriteString(fmt.Sprintf("* `%s`\n", test))
+	}
+	return strings.TrimSpace(report.String())
+}
+
+// removedTestsReport creates a markdown list of test files which are removed or shrunk
+func removedTestsReport(tests []string) string {
+	if len(tests) == 0 {
+		return ""
+	}
+	var report strings.Builder
+	report.WriteString("The following test files are removed or shrunk without adding equivalent tests:\n\n")
+	for _, test := range tests {
+		report.WriteString(fmt.Sprintf("* `%s`\n", test))
+	}
+	return strings.TrimSpace(report.String())
+}
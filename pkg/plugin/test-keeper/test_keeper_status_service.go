@@ -2,8 +2,10 @@ package testkeeper
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/arquillian/ike-prow-plugins/pkg/github"
+	ghclient "github.com/arquillian/ike-prow-plugins/pkg/github/client"
 	ghservice "github.com/arquillian/ike-prow-plugins/pkg/github/service"
 	"github.com/arquillian/ike-prow-plugins/pkg/log"
 	"github.com/arquillian/ike-prow-plugins/pkg/scm"
@@ -29,18 +31,58 @@ const (
 	// NoTestsDetailsPageName is a name of a documentation page that contains additional status details for NoTestsMessage
 	NoTestsDetailsPageName = "no-tests"
 
+	// InsufficientTestsMessage is a message used in GH Status as description when the PR doesn't satisfy configured test thresholds
+	InsufficientTestsMessage = "Not enough tests in this PR :("
+	// InsufficientTestsDetailsPageName is a name of a documentation page that contains additional status details for InsufficientTestsMessage
+	InsufficientTestsDetailsPageName = "insufficient-tests"
+
+	// MissingTestsMessage is a message used in GH Status as description when some changed files are missing the paired tests
+	MissingTestsMessage = "Some changed files are missing paired tests :("
+	// MissingTestsDetailsPageName is a name of a documentation page that contains additional status details for MissingTestsMessage
+	MissingTestsDetailsPageName = "missing-tests"
+
+	// RemovedTestsMessage is a message used in GH Status as description when tests are removed without adding equivalent ones
+	RemovedTestsMessage = "Some tests have been removed in this PR :("
+	// RemovedTestsWarningMessage is a message used in GH Status as description when the check passes, but tests are removed
+	RemovedTestsWarningMessage = "Passed, but some tests have been removed in this PR - please double-check"
+	// RemovedTestsDetailsPageName is a name of a documentation page that contains additional status details for RemovedTestsMessage
+	RemovedTestsDetailsPageName = "removed-tests"
+
+	// OkSmallChangeMessage is a message used in GH Status as description when PR is smaller than the configured size threshold
+	OkSmallChangeMessage = "This PR is small enough to go without tests"
+	// OkSmallChangeDetailsPageName is a name of a documentation page that contains additional status details for OkSmallChangeMessage
+	OkSmallChangeDetailsPageName = "small-change"
+
 	// OkOnlySkippedFilesMessage is a message used in GH Status as description when PR comes with a changeset which shouldn't be subject of test verification
 	OkOnlySkippedFilesMessage = "Seems that this PR doesn't need to have tests"
 	// OkOnlySkippedFilesDetailsPageName is a name of a documentation page that contains additional status details for OkOnlySkippedFilesMessage
 	OkOnlySkippedFilesDetailsPageName = "only-skipped"
 
+	// TooManyFilesMessage is a message used in GH Status as description when the PR changes more files than GitHub lists
+	// and none of the listed ones is a test
+	TooManyFilesMessage = "Too many changed files to verify the tests :("
+	// TooManyFilesDetailsPageName is a name of a documentation page that contains additional status details for TooManyFilesMessage
+	TooManyFilesDetailsPageName = "too-many-files"
+
 	// FailureMessage is a message used in GH Status as description when failure occurred
 	FailureMessage = "Failed while check for tests"
 
 	// ApprovedByMessage is a message used in GH Status as description when it's commented to skip the check
 	ApprovedByMessage = "PR is fine without tests says @%s"
+	// ApprovedByWithReasonMessage is a message used in GH Status as description when it's commented to skip the check with a reason
+	ApprovedByWithReasonMessage = "PR is fine without tests says @%s because: %s"
+	// ApprovedByLabelMessage is a message used in GH Status as description when the check is skipped by a bypass label
+	ApprovedByLabelMessage = "PR is fine without tests says @%s using %s label"
 	// ApprovedByDetailsPageName is a name of a documentation page that contains additional status details for ApprovedByMessage
 	ApprovedByDetailsPageName = "keeper-approved-by"
+
+	// BypassExpiredMessage is a message used in GH Status as description when the skip of the check has expired by new commits
+	BypassExpiredMessage = "Approval without tests by @%s expired by new commits :("
+	// BypassExpiredDetailsPageName is a name of a documentation page that contains additional status details for BypassExpiredMessage
+	BypassExpiredDetailsPageName = "bypass-expired"
+
+	// maxDescriptionLength is the maximal length of GH Status description
+	maxDescriptionLength = 140
 )
 
 func (gh *GitHubTestEventsHandler) newTestStatusService(logger log.Logger, pullRequest *gogh.PullRequest) *testStatusService {
@@ -62,8 +104,24 @@ func (ts *testStatusService) okOnlySkippedFiles() error {
 	return ts.statusService.Success(OkOnlySkippedFilesMessage, OkOnlySkippedFilesDetailsPageName)
 }
 
-func (ts *testStatusService) okWithoutTests(approvedBy string) error {
-	return ts.statusService.Success(fmt.Sprintf(ApprovedByMessage, approvedBy), ApprovedByDetailsPageName)
+func (ts *testStatusService) okSmallChange() error {
+	return ts.statusService.Success(OkSmallChangeMessage, OkSmallChangeDetailsPageName)
+}
+
+func (ts *testStatusService) okWithoutTests(bypassed *bypass) error {
+	return ts.statusService.Success(truncate(bypassed.description(), maxDescriptionLength), ApprovedByDetailsPageName)
+}
+
+func (ts *testStatusService) failBypassExpired(approvedBy string) error {
+	return ts.statusService.Failure(fmt.Sprintf(BypassExpiredMessage, approvedBy), BypassExpiredDetailsPageName)
+}
+
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-3]) + "..."
 }
 
 func (ts *testStatusService) reportError() error {
@@ -74,6 +132,26 @@ func (ts *testStatusService) failNoTests() error {
 	return ts.statusService.Failure(NoTestsMessage, NoTestsDetailsPageName)
 }
 
+func (ts *testStatusService) failMissingTests() error {
+	return ts.statusService.Failure(MissingTestsMessage, MissingTestsDetailsPageName)
+}
+
+func (ts *testStatusService) failRemovedTests() error {
+	return ts.statusService.Failure(RemovedTestsMessage, RemovedTestsDetailsPageName)
+}
+
+func (ts *testStatusService) warnRemovedTests() error {
+	return ts.statusService.Success(RemovedTestsWarningMessage, RemovedTestsDetailsPageName)
+}
+
+func (ts *testStatusService) failInsufficientTests() error {
+	return ts.statusService.Failure(InsufficientTestsMessage, InsufficientTestsDetailsPageName)
+}
+
+func (ts *testStatusService) failTooManyFiles() error {
+	return ts.statusService.Failure(TooManyFilesMessage, TooManyFilesDetailsPageName)
+}
+
 const (
 	paragraph = "\n\n"
 
@@ -93,6 +171,34 @@ const (
 	// OnlySkippedMsg contains a status message related to the state when PR is updated so it contains only skipped files
 	OnlySkippedMsg = "It seems that this PR doesn't need any test as all changed files in the changeset match " +
 		"patterns for which the validation should be skipped."
+
+	// InsufficientTestsMsg contains a status message related to the state when PR contains tests, but not as many as required
+	InsufficientTestsMsg = "It appears that this PR contains some tests, but not enough of them compared to the changes in the production code." +
+		paragraph +
+		"If you are an admin or the reviewer of this PR and you are sure that no more tests are needed then you can use the command `" + BypassCheckComment + "` " +
+		"as a comment to make the status green.\n"
+
+	// MissingTestsMsg contains a status message related to the state when PR contains tests, but some of the changed files are missing the paired ones
+	MissingTestsMsg = "It appears that this PR contains some tests, but not for all of the changed files the configured pairing rules require them for." +
+		paragraph +
+		"If you are an admin or the reviewer of this PR and you are sure that no more tests are needed then you can use the command `" + BypassCheckComment + "` " +
+		"as a comment to make the status green.\n"
+
+	// RemovedTestsMsg contains a status message related to the state when PR removes tests without adding equivalent ones
+	RemovedTestsMsg = "It appears that this PR removes more tests than it adds." +
+		paragraph +
+		"If you are an admin or the reviewer of this PR and you are sure that the tests can be removed then you can use the command `" + BypassCheckComment + "` " +
+		"as a comment to make the status green.\n"
+
+	// TooManyFilesMsg contains a status message related to the state when PR changes more files than GitHub lists and none of the listed ones is a test
+	TooManyFilesMsg = "It appears that this PR changes more files than GitHub is able to list and none of the listed files is a test, " +
+		"so it can't be verified whether the PR comes with tests." +
+		paragraph +
+		"Consider splitting the PR into smaller ones. If you are an admin or the reviewer of this PR and you are sure that no test is needed " +
+		"then you can use the command `" + BypassCheckComment + "` as a comment to make the status green.\n"
+
+	// SmallChangeMsg contains a status message related to the state when PR is smaller than the configured size threshold
+	SmallChangeMsg = "It seems that this PR doesn't need any test as the change of the production code is small enough."
 )
 
 type testStatusServiceWithMessages struct {
@@ -115,13 +221,57 @@ func (gh *GitHubTestEventsHandler) newTestStatusServiceWithMessages(logger log.L
 }
 
 // CreateWithoutTestsMessage creates a status message for the test-keeper plugin. If the status message is set in config then it takes that one, the default otherwise.
-func (ts *testStatusServiceWithMessages) withoutTestsMessage() {
-	ts.statusMsgService.SadStatusMessage(WithoutTestsMsg, "without_tests", true)
+func (ts *testStatusServiceWithMessages) withoutTestsMessage(reports ...string) {
+	ts.statusMsgService.SadStatusMessage(withReport(WithoutTestsMsg, reports...), "without_tests", true)
 }
 
 // CreateWithTestsMessage creates a status message for the test-keeper plugin. If the status message is set in config then it takes that one, the default otherwise.
-func (ts *testStatusServiceWithMessages) withTestsMessage() {
-	ts.statusMsgService.HappyStatusMessage(WithTestsMsg, "with_tests", false)
+func (ts *testStatusServiceWithMessages) withTestsMessage(reports ...string) {
+	ts.statusMsgService.HappyStatusMessage(withReport(WithTestsMsg, reports...), "with_tests", false)
+}
+
+// insufficientTestsMessage creates a status message for the test-keeper plugin. If the status message is set in config then it takes that one, the default otherwise.
+func (ts *testStatusServiceWithMessages) insufficientTestsMessage(reports ...string) {
+	ts.statusMsgService.SadStatusMessage(withReport(InsufficientTestsMsg, reports...), "insufficient_tests", true)
+}
+
+// missingTestsMessage creates a status message for the test-keeper plugin. If the status message is set in config then it takes that one, the default otherwise.
+func (ts *testStatusServiceWithMessages) missingTestsMessage(reports ...string) {
+	ts.statusMsgService.SadStatusMessage(withReport(MissingTestsMsg, reports...), "missing_tests", true)
+}
+
+// removedTestsMessage creates a status message for the test-keeper plugin. If the status message is set in config then it takes that one, the default otherwise.
+func (ts *testStatusServiceWithMessages) removedTestsMessage(reports ...string) {
+	ts.statusMsgService.SadStatusMessage(withReport(RemovedTestsMsg, reports...), "removed_tests", true)
+}
+
+// smallChangeMessage creates a status message for the test-keeper plugin. If the status message is set in config then it takes that one, the default otherwise.
+func (ts *testStatusServiceWithMessages) smallChangeMessage(reports ...string) {
+	ts.statusMsgService.HappyStatusMessage(withReport(SmallChangeMsg, reports...), "small_change", false)
+}
+
+// tooManyFilesMessage creates a status message for the test-keeper plugin. If the status message is set in config then it takes that one, the default otherwise.
+func (ts *testStatusServiceWithMessages) tooManyFilesMessage(reports ...string) {
+	ts.statusMsgService.SadStatusMessage(withReport(TooManyFilesMsg, reports...), "too_many_files", true)
+}
+
+// truncatedFilesReport creates a note saying that the decision is based on a partial list of the changed files
+func truncatedFilesReport(truncated *ghclient.TruncatedFilesError) string {
+	if truncated.Total == 0 {
+		return fmt.Sprintf("Note: GitHub has listed only the first %d files changed in this PR, "+
+			"so the decision is based on a partial list of the files.", truncated.Listed)
+	}
+	return fmt.Sprintf("Note: GitHub has listed only %d of %d files changed in this PR, "+
+		"so the decision is based on a partial list of the files.", truncated.Listed, truncated.Total)
+}
+
+func withReport(msg string, reports ...string) string {
+	for _, report := range reports {
+		if report != "" {
+			msg = strings.TrimSpace(msg) + paragraph + report
+		}
+	}
+	return msg
 }
 
 // CreateOnlySkippedMessage creates a status message for the test-keeper plugin. If the status message is set in config then it takes that one, the default otherwise.
@@ -0,0 +1,58 @@
+package testkeeper
+
+import (
+	"fmt"
+	"strings"
+)
+
+// thresholdsConfigured answers if any of the thresholds for the amount of tests or for the size of the change is set
+func (c *PluginConfiguration) thresholdsConfigured() bool {
+	return c.MinTestLinesRatio > 0 || c.MinTestFilesRatio > 0 || c.SkipValidationBelowLines > 0
+}
+
+// belowSizeThreshold answers if the number of lines added to and deleted from the production code is lower than
+// the configured size threshold, so no test is required. The deletions are counted as well, as removing or rewriting
+// the production code can change its behavior as much as adding a new one
+func (c *PluginConfiguration) belowSizeThreshold(categories FileCategories) bool {
+	changedLines := categories.ProductionAdditions + categories.ProductionDeletions
+	return c.SkipValidationBelowLines > 0 && changedLines < c.SkipValidationBelowLines
+}
+
+// ratioThresholdsSatisfied answers if the changeset contains enough tests with respect to the configured ratios
+// of test lines and test files to the production ones
+func (c *PluginConfiguration) ratioThresholdsSatisfied(categories FileCategories) bool {
+	return ratioSatisfied(categories.TestAdditions, categories.ProductionAdditions, c.MinTestLinesRatio) &&
+		ratioSatisfied(categories.Tests, categories.Production, c.MinTestFilesRatio)
+}
+
+func ratioSatisfied(tests, production int, min float64) bool {
+	return min <= 0 || production == 0 || float64(tests)/float64(production) >= min
+}
+
+func formatRatio(tests, production int) string {
+	if production == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.2f", float64(tests)/float64(production))
+}
+
+// thresholdsReport creates a markdown summary of the numbers the configured thresholds are computed from
+func (c *PluginConfiguration) thresholdsReport(categories FileCategories) string {
+	var report strings.Builder
+	report.WriteString("| | Production code | Tests |\n|---|---|---|\n")
+	report.WriteString(fmt.Sprintf("| Changed files | %d | %d |\n", categories.Production, categories.Tests))
+	report.WriteString(fmt.Sprintf("| Added lines | %d | %d |\n\n", categories.ProductionAdditions, categories.TestAdditions))
+
+	if c.MinTestLinesRatio > 0 {
+		report.WriteString(fmt.Sprintf("* ratio of added test lines to added production lines is %s (required at least %.2f)\n",
+			formatRatio(categories.TestAdditions, categories.ProductionAdditions), c.MinTestLinesRatio))
+	}
+	if c.MinTestFilesRatio > 0 {
+		report.WriteString(fmt.Sprintf("* ratio of test files to production files is %s (required at least %.2f)\n",
+			formatRatio(categories.Tests, categories.Production), c.MinTestFilesRatio))
+	}
+	if c.SkipValidationBelowLines > 0 {
+		report.WriteString(fmt.Sprintf("* no test is required when less than %d production lines are added or deleted\n", c.SkipValidationBelowLines))
+	}
+	return strings.TrimSpace(report.String())
+}
@@ -37,7 +37,7 @@ type GitHubWIPPRHandler struct {
 }
 
 var (
-	handledCommentActions = []string{"created", "edited"}
+	handledCommentActions = []string{"created", "edited", "submitted"}
 	handledPrActions      = []string{"opened", "reopened", "edited", "synchronize", "labeled", "unlabeled"}
 	defaultPrefixes       = []string{"WIP", "DO NOT MERGE", "DON'T MERGE", "WORK-IN-PROGRESS"}
 )
@@ -49,51 +49,71 @@ func (gh *GitHubWIPPRHandler) HandlePullRequestEvent(logger log.Logger, event *g
 		return nil
 	}
 
+	configuration := LoadConfiguration(logger, ghservice.NewRepositoryChangeForPR(event.PullRequest))
 	switch *event.Action {
 	case github.ActionLabeled, github.ActionUnlabeled:
-		return gh.checkComponentsAndSetStatus(logger, event.PullRequest, true)
+		return gh.checkComponentsAndSetStatus(logger, event.PullRequest, configuration, true)
 	default:
-		return gh.checkComponentsAndSetStatus(logger, event.PullRequest, false)
+		return gh.checkComponentsAndSetStatus(logger, event.PullRequest, configuration, false)
 	}
 }
 
 // HandleIssueCommentEvent is an entry point for the plugin logic. This method is invoked by the Server when
 // issue comment event is dispatched from the /hook service
 func (gh *GitHubWIPPRHandler) HandleIssueCommentEvent(logger log.Logger, comment *gogh.IssueCommentEvent) error {
-	if !utils.Contains(handledCommentActions, *comment.Action) {
+	return gh.handleCommand(logger, command.NewIssueCommentSource(comment))
+}
+
+// HandlePullRequestReviewEvent is an entry point for the plugin logic. This method is invoked by the Server when
+// pull request review event is dispatched from the /hook service
+func (gh *GitHubWIPPRHandler) HandlePullRequestReviewEvent(logger log.Logger, review *gogh.PullRequestReviewEvent) error {
+	return gh.handleCommand(logger, command.NewReviewSource(review))
+}
+
+// HandlePullRequestReviewCommentEvent is an entry point for the plugin logic. This method is invoked by the Server when
+// pull request review comment event is dispatched from the /hook service
+func (gh *GitHubWIPPRHandler) HandlePullRequestReviewCommentEvent(logger log.Logger, comment *gogh.PullRequestReviewCommentEvent) error {
+	return gh.handleCommand(logger, command.NewReviewCommentSource(comment))
+}
+
+func (gh *GitHubWIPPRHandler) handleCommand(logger log.Logger, source *command.CmdSource) error {
+	if !utils.Contains(handledCommentActions, source.Action) {
 		return nil
 	}
 
-	prLoader := ghservice.NewPullRequestLazyLoaderFromComment(gh.Client, comment)
-	userPerm := command.NewPermissionService(gh.Client, *comment.Sender.Login, prLoader)
+	prLoader := source.PullRequestLoader(gh.Client)
+	userPerm := command.NewPermissionService(gh.Client, source.Author, prLoader)
+	commandsConfig := command.NewConfigurationLoader(logger, ProwPluginName, prLoader)
 
-	cmdHandler := command.CommentCmdHandler{Client: gh.Client}
+	cmdHandler := command.CommentCmdHandler{Client: gh.Client, PluginName: ProwPluginName}
 	cmdHandler.Register(&command.RunCmd{
 		PluginName:            ProwPluginName,
 		UserPermissionService: userPerm,
+		Permissions:           commandsConfig.Permissions,
+		Feedback:              commandsConfig.Feedback,
 		WhenAddedOrEdited: func() error {
 			pullRequest, err := prLoader.Load()
 			if err != nil {
 				return err
 			}
 
-			return gh.checkComponentsAndSetStatus(logger, pullRequest, false)
+			return gh.checkComponentsAndSetStatus(logger, pullRequest, LoadConfiguration(logger, ghservice.NewRepositoryChangeForPR(pullRequest)), false)
 
 		}})
 
-	err := cmdHandler.Handle(logger, comment)
+	err := cmdHandler.Handle(logger, source)
 	if err != nil {
 		logger.Error(err)
 	}
 	return err
 }
 
-func (gh *GitHubWIPPRHandler) checkComponentsAndSetStatus(logger log.Logger, pullRequest *gogh.PullRequest, labelUpdated bool) error {
+func (gh *GitHubWIPPRHandler) checkComponentsAndSetStatus(logger log.Logger, pullRequest *gogh.PullRequest,
+	configuration PluginConfiguration, labelUpdated bool) error {
 	change := ghservice.NewRepositoryChangeForPR(pullRequest)
 	statusContext := github.StatusContext{BotName: gh.BotName, PluginName: ProwPluginName}
 	statusService := status.NewStatusService(gh.Client, logger, change, statusContext)
 
-	configuration := LoadConfiguration(logger, change)
 	labelExists := gh.hasWorkInProgressLabel(pullRequest.Labels, configuration.Label)
 	prefix, prefixExists := GetWorkInProgressPrefix(*pullRequest.Title, configuration)
 
@@ -179,6 +179,7 @@ var _ = Describe("Work In Progress Plugin features", func() {
 		It("should mark opened PR as ready for review if not prefixed with WIP when "+command.RunCommentPrefix+" "+wip.ProwPluginName+" command is triggered by pr creator", func() {
 			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
 				WithTitle("PR from external user without tests should be rejected").
 				WithoutConfigFiles().
 				WithoutReviews().
@@ -200,6 +201,7 @@ var _ = Describe("Work In Progress Plugin features", func() {
 		It("should mark opened PR as work-in-progress if prefixed with WIP when "+command.RunCommentPrefix+" all command is used by admin", func() {
 			// given
 			prMock := mocker.MockPr().LoadedFromDefaultJSON().
+				WithoutBaseConfigFiles().
 				WithTitle("WIP PR from external user without tests should be rejected").
 				WithoutConfigFiles().
 				WithoutReviews().
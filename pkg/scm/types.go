@@ -19,11 +19,13 @@ func NewRepositoryIssue(owner, repoName string, number int) *RepositoryIssue {
 }
 
 // ChangedFile is a type that contains information about created/modified/removed file within an scm repository
+// together with the unified diff of the change (the patch may be missing e.g. for binary or too large files)
 type ChangedFile struct {
 	Name      string
 	Status    string
 	Additions int
 	Deletions int
+	Patch     string
 }
 
 // RepositoryChange holds information about owner and repository to which the change indicated by Hash belongs
@@ -40,5 +42,6 @@ func NewChangedFile(file *gogh.CommitFile) *ChangedFile {
 		Status:    *file.Status,
 		Additions: *file.Additions,
 		Deletions: *file.Deletions,
+		Patch:     file.GetPatch(),
 	}
 }
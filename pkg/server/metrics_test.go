@@ -30,6 +30,14 @@ func (gh *DummyGHEventHandler) HandleIssueCommentEvent(logger log.Logger, event
 	return nil
 }
 
+func (gh *DummyGHEventHandler) HandlePullRequestReviewEvent(logger log.Logger, event *gogh.PullRequestReviewEvent) error {
+	return nil
+}
+
+func (gh *DummyGHEventHandler) HandlePullRequestReviewCommentEvent(logger log.Logger, event *gogh.PullRequestReviewCommentEvent) error {
+	return nil
+}
+
 var _ = Describe("Service Metrics", func() {
 	secret := []byte("123abc")
 	client := NewDefaultGitHubClient()
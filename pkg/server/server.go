@@ -17,6 +17,8 @@ import (
 type GitHubEventHandler interface {
 	HandlePullRequestEvent(logger log.Logger, event *gogh.PullRequestEvent) error
 	HandleIssueCommentEvent(logger log.Logger, event *gogh.IssueCommentEvent) error
+	HandlePullRequestReviewEvent(logger log.Logger, event *gogh.PullRequestReviewEvent) error
+	HandlePullRequestReviewCommentEvent(logger log.Logger, event *gogh.PullRequestReviewCommentEvent) error
 }
 
 // Server implements http.Handler. It validates incoming GitHub webhooks and
@@ -86,6 +88,24 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			l.WithError(err).Errorf("error handling '%q' event with payload %+v.", github.IssueComment, event)
 			return
 		}
+	case github.PullRequestReview:
+		var event gogh.PullRequestReviewEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			l.WithError(err).Errorf("failed while parsing '%q' event with payload: %+v.", github.PullRequestReview, event)
+		}
+		if err := s.GitHubEventHandler.HandlePullRequestReviewEvent(l, &event); err != nil {
+			l.WithError(err).Errorf("error handling '%q' event with payload %+v.", github.PullRequestReview, event)
+			return
+		}
+	case github.PullRequestReviewComment:
+		var event gogh.PullRequestReviewCommentEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			l.WithError(err).Errorf("failed while parsing '%q' event with payload: %+v.", github.PullRequestReviewComment, event)
+		}
+		if err := s.GitHubEventHandler.HandlePullRequestReviewCommentEvent(l, &event); err != nil {
+			l.WithError(err).Errorf("error handling '%q' event with payload %+v.", github.PullRequestReviewComment, event)
+			return
+		}
 	default:
 		l.Warnf("received an event of type %q but didn't ask for it", eventType)
 	}
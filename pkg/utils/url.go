@@ -7,7 +7,23 @@ import (
 	"time"
 )
 
-// GetFileFromURL retrieves the content of the file on the given url
+// HTTPError is returned by GetFileFromURL when the server responds with an error status code
+type HTTPError struct {
+	StatusCode int
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("server responded with error %d", e.StatusCode)
+}
+
+// IsNotFound answers if the given error returned by GetFileFromURL says that the file doesn't exist
+func IsNotFound(err error) bool {
+	httpErr, ok := err.(*HTTPError)
+	return ok && httpErr.StatusCode == http.StatusNotFound
+}
+
+// GetFileFromURL retrieves the content of the file on the given url. When the server responds with an error status
+// code, then HTTPError is returned
 func GetFileFromURL(url string) ([]byte, error) {
 	client := &http.Client{
 		Timeout: time.Second * 10,
@@ -17,7 +33,7 @@ func GetFileFromURL(url string) ([]byte, error) {
 		return nil, err
 	}
 	if resp.StatusCode >= 400 {
-		return make([]byte, 0), fmt.Errorf("server responded with error %d", resp.StatusCode)
+		return make([]byte, 0), &HTTPError{StatusCode: resp.StatusCode}
 	}
 
 	defer func() {